@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/model"
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/query"
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+)
+
+const defaultSeatsPerOrder = 1
+
+var ErrSuspectedAbuse = errors.New("suspected abuse")
+
+// seatStatuses 占用订单名额的状态；失败、忽略的记录不占名额，买家改正用户名后可以重新邀请
+var seatStatuses = []string{InvitationStatusPending, InvitationStatusSucceeded, InvitationStatusResolved}
+
+// seatAllowance 每个订单可邀请的 GitHub 账号数，商品配置中的 seats 覆盖默认的 <来源>.seats_per_order
+func seatAllowance(p *Purchase) int64 {
+	seats := viper.GetInt64(p.Source + ".seats_per_order")
+	if seats <= 0 {
+		seats = defaultSeatsPerOrder
+	}
+	if v := cast.ToInt64(p.productConfig()["seats"]); v > 0 {
+		seats = v
+	}
+	return seats
+}
+
+// checkSeats 订单已被其他账号占满名额时返回 ErrSuspectedAbuse；
+// extra 为尚未写库、但已计划占用该订单的账号，试运行时使用
+func checkSeats(ctx context.Context, username string, p *Purchase, extra ...string) error {
+	orderID := p.OrderID
+	t := query.InvitationModel
+	var holders []string
+	if err := t.WithContext(ctx).
+		Distinct(t.GithubUsername).
+		Where(
+			t.OrderSource.Eq(p.Source),
+			t.OrderID.Eq(orderID),
+			t.GithubUsername.Neq(username),
+			t.InvitationStatus.In(seatStatuses...),
+		).
+		Pluck(t.GithubUsername, &holders); err != nil {
+		return fmt.Errorf("count seats error||err=%v", err)
+	}
+	// 用于续费的订单同样占用名额
+	var renewers []string
+	r := query.RenewalModel
+	if err := r.WithContext(ctx).
+		Distinct(r.GithubUsername).
+		Where(r.OrderSource.Eq(p.Source), r.OrderID.Eq(orderID), r.GithubUsername.Neq(username)).
+		Pluck(r.GithubUsername, &renewers); err != nil {
+		return fmt.Errorf("count renewal seats error||err=%v", err)
+	}
+	for _, name := range append(renewers, extra...) {
+		if name != username && !slices.Contains(holders, name) {
+			holders = append(holders, name)
+		}
+	}
+	if allowance := seatAllowance(p); int64(len(holders)) >= allowance {
+		return fmt.Errorf("%w: order %d already used by %v, allowance=%d", ErrSuspectedAbuse, orderID, holders, allowance)
+	}
+	return nil
+}
+
+// AbuseReportItem 可疑模式：Key 被多个 Values 共用
+type AbuseReportItem struct {
+	Key    string   `json:"key"`
+	Count  int64    `json:"count"`
+	Values []string `json:"values"`
+}
+
+// abuseReport 列出被多个账号共用的订单、邮箱，以及绑定了多个订单或邮箱的账号
+func abuseReport(w http.ResponseWriter, r *http.Request) {
+	var (
+		err        error
+		statusCode = http.StatusOK
+	)
+	defer func() {
+		if err != nil {
+			writeError(w, r, statusCode, err)
+		}
+	}()
+	if r.Method != http.MethodGet {
+		err = errors.New("method not allowed")
+		statusCode = http.StatusMethodNotAllowed
+		return
+	}
+
+	report := make(map[string][]AbuseReportItem)
+	for name, cols := range map[string][2]string{
+		"orders_shared_by_accounts": {"order_source || ':' || order_id", "github_username"},
+		"emails_shared_by_accounts": {"lower(github_email)", "github_username"},
+		"accounts_with_many_orders": {"github_username", "order_source || ':' || order_id"},
+		"accounts_with_many_emails": {"github_username", "lower(github_email)"},
+	} {
+		if report[name], err = sharedPatterns(r.Context(), cols[0], cols[1]); err != nil {
+			statusCode = http.StatusInternalServerError
+			return
+		}
+	}
+	var flagged int64
+	if flagged, err = query.InvitationModel.WithContext(r.Context()).
+		Where(query.InvitationModel.InvitationStatus.Eq(InvitationStatusSuspectedAbuse)).
+		Count(); err != nil {
+		statusCode = http.StatusInternalServerError
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, map[string]any{
+		"suspected_abuse_cnt": flagged,
+		"patterns":            report,
+	})
+}
+
+// sharedPatterns 按 key 分组，找出对应多个不同 value 的分组；忽略、失败的记录也计入，便于发现反复尝试
+func sharedPatterns(ctx context.Context, key, value string) ([]AbuseReportItem, error) {
+	var rows []struct {
+		PatternKey string
+		MemberCnt  int64
+		Members    string
+	}
+	err := query.InvitationModel.WithContext(ctx).UnderlyingDB().
+		Table(model.TableNameInvitationModel).
+		Select(fmt.Sprintf("%s AS pattern_key, COUNT(DISTINCT %s) AS member_cnt, string_agg(DISTINCT %s, ',') AS members", key, value, value)).
+		Group(key).
+		Having(fmt.Sprintf("COUNT(DISTINCT %s) > 1", value)).
+		Order("member_cnt DESC").
+		Limit(200).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("query shared patterns error||key=%s||err=%v", key, err)
+	}
+	items := make([]AbuseReportItem, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, AbuseReportItem{Key: row.PatternKey, Count: row.MemberCnt, Values: strings.Split(row.Members, ",")})
+	}
+	return items, nil
+}
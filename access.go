@@ -0,0 +1,361 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/model"
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/query"
+	"github.com/google/uuid"
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+)
+
+const (
+	// AccessListBlock 命中的行不邀请；AccessListAllow 只豁免 email_domain 的封禁，其他封禁规则仍然生效
+	AccessListBlock = "block"
+	AccessListAllow = "allow"
+
+	AccessKindUsername    = "username"
+	AccessKindUserID      = "user_id"
+	AccessKindEmail       = "email"
+	AccessKindEmailDomain = "email_domain"
+	AccessKindOrderID     = "order_id"
+)
+
+var (
+	accessLists = []string{AccessListBlock, AccessListAllow}
+	accessKinds = []string{AccessKindUsername, AccessKindUserID, AccessKindEmail, AccessKindEmailDomain, AccessKindOrderID}
+)
+
+var ErrBlocked = errors.New("blocked")
+
+// checkAccess 在任何 GitHub 调用之前检查名单：封禁名单优先，
+// 放行规则只豁免按邮箱域名的封禁，用于在封禁整个域名时放行其中的个别用户。
+// 只有存在生效的 user_id 规则时才查询 GitHub 用户 ID
+func checkAccess(ctx context.Context, content Range) error {
+	t := query.AccessRuleModel
+	email := strings.ToLower(content.GithubEmail)
+	_, domain, _ := strings.Cut(email, "@")
+	rules, err := t.WithContext(ctx).Where(activeAccessRule()).Where(field.Or(
+		field.And(t.Kind.Eq(AccessKindUsername), t.Value.Eq(strings.ToLower(content.GithubUsername))),
+		field.And(t.Kind.Eq(AccessKindEmail), t.Value.Eq(email)),
+		field.And(t.Kind.Eq(AccessKindEmailDomain), t.Value.Eq(domain)),
+		field.And(t.Kind.Eq(AccessKindOrderID), t.Value.Eq(strconv.FormatInt(content.OrderID, 10)),
+			t.OrderSource.In("", content.orderSource())),
+	)).Find()
+	if err != nil {
+		return fmt.Errorf("find_access_rules_error||err=%v", err)
+	}
+
+	userIDRules, err := t.WithContext(ctx).Where(activeAccessRule(), t.Kind.Eq(AccessKindUserID)).Count()
+	if err != nil {
+		return fmt.Errorf("count_access_rules_error||err=%v", err)
+	}
+	if userIDRules > 0 {
+		id, err := GetUserID(content.GithubUsername)
+		// 用户不存在时交给后续邀请流程报告
+		if err != nil && !errors.Is(err, ErrGithubUserNotFound) {
+			return fmt.Errorf("check_access_error||err=%v", err)
+		}
+		if err == nil {
+			byID, err := t.WithContext(ctx).
+				Where(activeAccessRule(), t.Kind.Eq(AccessKindUserID), t.Value.Eq(strconv.FormatInt(id, 10))).
+				Find()
+			if err != nil {
+				return fmt.Errorf("find_access_rules_error||err=%v", err)
+			}
+			rules = append(rules, byID...)
+		}
+	}
+
+	rule := blockingRule(rules)
+	if rule == nil {
+		return nil
+	}
+	reason := rule.Reason
+	if reason == "" {
+		reason = "no reason given"
+	}
+	return fmt.Errorf("%w by %s %s: %s", ErrBlocked, rule.Kind, rule.Value, reason)
+}
+
+// blockingRule 命中的规则中生效的封禁规则，没有时返回 nil
+func blockingRule(rules []*model.AccessRuleModel) *model.AccessRuleModel {
+	allowed := slices.ContainsFunc(rules, func(rule *model.AccessRuleModel) bool { return rule.List == AccessListAllow })
+	var byDomain *model.AccessRuleModel
+	for _, rule := range rules {
+		if rule.List != AccessListBlock {
+			continue
+		}
+		if rule.Kind != AccessKindEmailDomain {
+			return rule
+		}
+		if byDomain == nil && !allowed {
+			byDomain = rule
+		}
+	}
+	return byDomain
+}
+
+// activeAccessRule 未设置到期时间或尚未到期
+func activeAccessRule() gen.Condition {
+	t := query.AccessRuleModel
+	return field.Or(t.ExpiresAt.Lte(epochFloor), t.ExpiresAt.Gt(time.Now()))
+}
+
+// AccessRuleRequest POST /access-rules 的请求体；ExpiresAt 为空表示永久有效，格式同 /success 的 from、to
+type AccessRuleRequest struct {
+	List        string `json:"list"`
+	Kind        string `json:"kind"`
+	Value       string `json:"value"`
+	OrderSource string `json:"order_source"`
+	Reason      string `json:"reason"`
+	ExpiresAt   string `json:"expires_at"`
+}
+
+// UpdateAccessRuleRequest PATCH /access-rules/{id} 的请求体，未出现的字段不修改；ExpiresAt 为空字符串表示永久有效
+type UpdateAccessRuleRequest struct {
+	Reason    *string `json:"reason"`
+	ExpiresAt *string `json:"expires_at"`
+}
+
+// rule 校验并规范化：值统一为小写，邮箱域名去掉开头的 @
+func (req AccessRuleRequest) rule() (*model.AccessRuleModel, error) {
+	rule := &model.AccessRuleModel{
+		ID:          uuid.New().String(),
+		List:        req.List,
+		Kind:        req.Kind,
+		Value:       strings.ToLower(strings.TrimSpace(req.Value)),
+		OrderSource: req.OrderSource,
+		Reason:      strings.TrimSpace(req.Reason),
+	}
+	if rule.List == "" {
+		rule.List = AccessListBlock
+	}
+	if !slices.Contains(accessLists, rule.List) {
+		return nil, fmt.Errorf("invalid params, unknown list=%s", req.List)
+	}
+	if !slices.Contains(accessKinds, rule.Kind) {
+		return nil, fmt.Errorf("invalid params, unknown kind=%s", req.Kind)
+	}
+	if rule.Kind == AccessKindEmailDomain {
+		rule.Value = strings.TrimPrefix(rule.Value, "@")
+	}
+	if rule.Value == "" {
+		return nil, errors.New("invalid params, empty value")
+	}
+	switch rule.Kind {
+	case AccessKindUserID, AccessKindOrderID:
+		if _, err := strconv.ParseInt(rule.Value, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid params, %s must be a number", rule.Kind)
+		}
+	case AccessKindEmail:
+		if addr, err := mail.ParseAddress(rule.Value); err != nil || addr.Address != rule.Value {
+			return nil, fmt.Errorf("invalid params, invalid email=%s", req.Value)
+		}
+	}
+	switch rule.OrderSource {
+	case "":
+	case OrderSourceBilibili, OrderSourceAfdian:
+		if rule.Kind != AccessKindOrderID {
+			return nil, errors.New("invalid params, order_source only applies to order_id")
+		}
+	default:
+		return nil, fmt.Errorf("invalid params, unknown order_source=%s", req.OrderSource)
+	}
+	expiresAt, err := parseAccessExpiry(req.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	rule.ExpiresAt = expiresAt
+	return rule, nil
+}
+
+// parseAccessExpiry 空字符串表示永久有效，存为 epoch
+func parseAccessExpiry(s string) (time.Time, error) {
+	if s == "" {
+		return time.Unix(0, 0), nil
+	}
+	t, err := parseListTime(s, false)
+	if err != nil {
+		return t, fmt.Errorf("invalid params, expires_at=%s", s)
+	}
+	return t, nil
+}
+
+func findAccessRule(ctx context.Context, id string) (*model.AccessRuleModel, int, error) {
+	if err := uuid.Validate(id); err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid params, id=%s", id)
+	}
+	t := query.AccessRuleModel
+	rule, err := t.WithContext(ctx).Where(t.ID.Eq(id)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, http.StatusNotFound, fmt.Errorf("access rule %s not found", id)
+	}
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("find_access_rule_error||err=%v", err)
+	}
+	return rule, http.StatusOK, nil
+}
+
+// listAccessRules GET /access-rules?list=&kind=&value=&include_expired=，value 为子串匹配，默认不含已到期的规则
+func listAccessRules(w http.ResponseWriter, r *http.Request) {
+	var (
+		err        error
+		statusCode = http.StatusOK
+		q          = r.URL.Query()
+		t          = query.AccessRuleModel
+		conds      []gen.Condition
+	)
+	defer func() {
+		if err != nil {
+			writeError(w, r, statusCode, err)
+		}
+	}()
+
+	if list := q.Get("list"); list != "" {
+		if !slices.Contains(accessLists, list) {
+			statusCode, err = http.StatusBadRequest, fmt.Errorf("invalid params, unknown list=%s", list)
+			return
+		}
+		conds = append(conds, t.List.Eq(list))
+	}
+	if kind := q.Get("kind"); kind != "" {
+		if !slices.Contains(accessKinds, kind) {
+			statusCode, err = http.StatusBadRequest, fmt.Errorf("invalid params, unknown kind=%s", kind)
+			return
+		}
+		conds = append(conds, t.Kind.Eq(kind))
+	}
+	if value := strings.TrimSpace(q.Get("value")); value != "" {
+		conds = append(conds, t.Value.Like(containsPattern(strings.ToLower(value))))
+	}
+	if q.Get("include_expired") != "true" {
+		conds = append(conds, activeAccessRule())
+	}
+	rules, err := t.WithContext(r.Context()).Where(conds...).Order(t.CreatedAt.Desc(), t.ID).Find()
+	if err != nil {
+		statusCode, err = http.StatusInternalServerError, fmt.Errorf("find_access_rules_error||err=%v", err)
+		return
+	}
+	writeJSON(w, r, http.StatusOK, ListResponse[*model.AccessRuleModel]{Total: int64(len(rules)), Items: rules})
+}
+
+// createAccessRule POST /access-rules，同一名单中已有相同规则时返回 409
+func createAccessRule(w http.ResponseWriter, r *http.Request) {
+	var (
+		err        error
+		statusCode = http.StatusOK
+		req        AccessRuleRequest
+		t          = query.AccessRuleModel
+	)
+	defer func() {
+		if err != nil {
+			writeError(w, r, statusCode, err)
+		}
+	}()
+
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		statusCode, err = http.StatusBadRequest, fmt.Errorf("bind request error, err=%w", err)
+		return
+	}
+	rule, err := req.rule()
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		return
+	}
+	// 相同规则由 access_rules_uk 拒绝，并发创建时也只有一个成功
+	err = t.WithContext(r.Context()).Create(rule)
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		statusCode, err = http.StatusConflict, fmt.Errorf("%s rule for %s %s already exists", rule.List, rule.Kind, rule.Value)
+		return
+	}
+	if err != nil {
+		statusCode, err = http.StatusInternalServerError, fmt.Errorf("create_access_rule_error||err=%v", err)
+		return
+	}
+	if rule, statusCode, err = findAccessRule(r.Context(), rule.ID); err != nil {
+		return
+	}
+	writeJSON(w, r, http.StatusCreated, rule)
+}
+
+// updateAccessRule PATCH /access-rules/{id}，只能修改原因和到期时间
+func updateAccessRule(w http.ResponseWriter, r *http.Request) {
+	var (
+		err        error
+		statusCode = http.StatusOK
+		req        UpdateAccessRuleRequest
+		t          = query.AccessRuleModel
+		id         = r.PathValue("id")
+	)
+	defer func() {
+		if err != nil {
+			writeError(w, r, statusCode, err)
+		}
+	}()
+
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		statusCode, err = http.StatusBadRequest, fmt.Errorf("bind request error, err=%w", err)
+		return
+	}
+	if _, statusCode, err = findAccessRule(r.Context(), id); err != nil {
+		return
+	}
+	updates := map[string]any{"updated_at": time.Now()}
+	if req.Reason != nil {
+		updates["reason"] = strings.TrimSpace(*req.Reason)
+	}
+	if req.ExpiresAt != nil {
+		expiresAt, err2 := parseAccessExpiry(*req.ExpiresAt)
+		if err2 != nil {
+			statusCode, err = http.StatusBadRequest, err2
+			return
+		}
+		updates["expires_at"] = expiresAt
+	}
+	if _, err = t.WithContext(r.Context()).Where(t.ID.Eq(id)).Updates(updates); err != nil {
+		statusCode, err = http.StatusInternalServerError, fmt.Errorf("update_access_rule_error||err=%v", err)
+		return
+	}
+	rule, statusCode, err := findAccessRule(r.Context(), id)
+	if err != nil {
+		return
+	}
+	writeJSON(w, r, http.StatusOK, rule)
+}
+
+// deleteAccessRule DELETE /access-rules/{id}，返回被删除的规则
+func deleteAccessRule(w http.ResponseWriter, r *http.Request) {
+	var (
+		err        error
+		statusCode = http.StatusOK
+		t          = query.AccessRuleModel
+		id         = r.PathValue("id")
+	)
+	defer func() {
+		if err != nil {
+			writeError(w, r, statusCode, err)
+		}
+	}()
+
+	rule, statusCode, err := findAccessRule(r.Context(), id)
+	if err != nil {
+		return
+	}
+	if _, err = t.WithContext(r.Context()).Where(t.ID.Eq(id)).Delete(); err != nil {
+		statusCode, err = http.StatusInternalServerError, fmt.Errorf("delete_access_rule_error||err=%v", err)
+		return
+	}
+	writeJSON(w, r, http.StatusOK, rule)
+}
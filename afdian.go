@@ -0,0 +1,431 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/md5"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/model"
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/query"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	SourceAfdian = "afdian"
+
+	afdianCodeOK = 200
+	// 爱发电订单状态，2 为交易成功
+	afdianOrderStatusPaid = 2
+
+	maxAfdianWebhookSize = 1 << 20
+)
+
+var (
+	afdianAPIToken string
+
+	// 未配置爱发电时为 nil
+	afdianClient   *afdianAPI
+	afdianVerifier PurchaseVerifier
+)
+
+var (
+	afdianGithubURLPattern   = regexp.MustCompile(`(?i)github\.com/([A-Za-z0-9-]+)`)
+	afdianGithubLabelPattern = regexp.MustCompile(`(?i)github\s*(?:id|username|用户名|账号)?\s*[:：=]\s*([A-Za-z0-9-]+)`)
+	afdianEmailPattern       = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+)
+
+// AfdianOrder 爱发电订单，字段与 webhook 和查询订单接口一致
+type AfdianOrder struct {
+	OutTradeNo  string `json:"out_trade_no"`
+	UserID      string `json:"user_id"`
+	PlanID      string `json:"plan_id"`
+	Month       int    `json:"month"`
+	TotalAmount string `json:"total_amount"`
+	Status      int    `json:"status"`
+	Remark      string `json:"remark"`
+}
+
+// AfdianWebhook 爱发电推送的请求体
+type AfdianWebhook struct {
+	EC   int    `json:"ec"`
+	EM   string `json:"em"`
+	Data struct {
+		Type  string      `json:"type"`
+		Order AfdianOrder `json:"order"`
+		Sign  string      `json:"sign"`
+	} `json:"data"`
+}
+
+// AfdianQueryOrderResponse 查询订单接口的返回
+type AfdianQueryOrderResponse struct {
+	EC   int    `json:"ec"`
+	EM   string `json:"em"`
+	Data struct {
+		List []AfdianOrder `json:"list"`
+	} `json:"data"`
+}
+
+// setupAfdian 配置了 afdian.user_id 和 AFDIAN_API_TOKEN 时启用爱发电订单来源
+func setupAfdian() error {
+	userID := viper.GetString("afdian.user_id")
+	if userID == "" || afdianAPIToken == "" {
+		logrus.Warnf("afdian.user_id or env '%s' is empty, afdian webhook disabled", EnvAfdianAPIToken)
+		return nil
+	}
+	publicKey, err := parseRSAPublicKey(viper.GetString("afdian.public_key"))
+	if err != nil {
+		return fmt.Errorf("invalid afdian.public_key||err=%w", err)
+	}
+	baseURL := viper.GetString("afdian.base_url")
+	if baseURL == "" {
+		baseURL = "https://afdian.com"
+	}
+	afdianClient = &afdianAPI{
+		baseURL:   strings.TrimRight(baseURL, "/"),
+		userID:    userID,
+		token:     afdianAPIToken,
+		publicKey: publicKey,
+	}
+	afdianVerifier = afdianClient
+	return nil
+}
+
+func parseRSAPublicKey(s string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(s))
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unexpected key type %T", key)
+	}
+	return publicKey, nil
+}
+
+// afdianAPI 爱发电开放接口
+type afdianAPI struct {
+	baseURL   string
+	userID    string
+	token     string
+	publicKey *rsa.PublicKey
+}
+
+// verifySign webhook 的签名为平台私钥对 out_trade_no、user_id、plan_id、total_amount 拼接串的 SHA256 签名
+func (a *afdianAPI) verifySign(order AfdianOrder, sign string) error {
+	signature, err := base64.StdEncoding.DecodeString(sign)
+	if err != nil {
+		return fmt.Errorf("decode sign error||err=%w", err)
+	}
+	digest := sha256.Sum256([]byte(order.OutTradeNo + order.UserID + order.PlanID + order.TotalAmount))
+	return rsa.VerifyPKCS1v15(a.publicKey, crypto.SHA256, digest[:], signature)
+}
+
+// afdianHTTPClient 公开的 /claim 和 webhook 都会查询订单，不能无限等待爱发电
+var afdianHTTPClient = newInstrumentedClient(upstreamAfdian, 10*time.Second, plainEndpoint)
+
+// QueryOrder 按 out_trade_no 查询订单，不存在时返回 nil
+func (a *afdianAPI) QueryOrder(ctx context.Context, outTradeNo string) (*AfdianOrder, error) {
+	params, err := json.Marshal(map[string]string{"out_trade_no": outTradeNo})
+	if err != nil {
+		return nil, err
+	}
+	ts := time.Now().Unix()
+	sum := md5.Sum([]byte(fmt.Sprintf("%sparams%sts%duser_id%s", a.token, params, ts, a.userID)))
+	data, err := json.Marshal(map[string]any{
+		"user_id": a.userID,
+		"params":  string(params),
+		"ts":      ts,
+		"sign":    hex.EncodeToString(sum[:]),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.baseURL+"/api/open/query-order", bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := afdianHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("do request error||err=%w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read body error||err=%w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status||code=%d||resp=%s", resp.StatusCode, string(body))
+	}
+
+	var r AfdianQueryOrderResponse
+	if err = json.Unmarshal(body, &r); err != nil {
+		return nil, fmt.Errorf("bind response error||resp=%s||err=%w", string(body), err)
+	}
+	if r.EC != afdianCodeOK {
+		return nil, fmt.Errorf("response ec non-200||resp=%s", string(body))
+	}
+	for _, order := range r.Data.List {
+		if order.OutTradeNo == outTradeNo {
+			return &order, nil
+		}
+	}
+	return nil, nil
+}
+
+// Verify 订单号为 afdian_orders.id，每次都向爱发电重新查询
+func (a *afdianAPI) Verify(ctx context.Context, orderID int64) (*Purchase, error) {
+	p := &Purchase{OrderID: orderID}
+	saved, err := query.AfdianOrderModel.WithContext(ctx).
+		Where(query.AfdianOrderModel.ID.Eq(orderID)).
+		First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return p, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find_afdian_order_error||err=%v", err)
+	}
+	order, err := a.QueryOrder(ctx, saved.OutTradeNo)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return p, nil
+	}
+	p.Exists = true
+	p.Paid = order.Status == afdianOrderStatusPaid
+	p.PlanID = order.PlanID
+	p.Status = strconv.Itoa(order.Status)
+	return p, nil
+}
+
+// saveAfdianOrder 按 out_trade_no 写入或更新订单，返回的记录带有 ID
+func saveAfdianOrder(ctx context.Context, order *AfdianOrder) (*model.AfdianOrderModel, error) {
+	saved := &model.AfdianOrderModel{
+		OutTradeNo:  order.OutTradeNo,
+		UserID:      order.UserID,
+		PlanID:      order.PlanID,
+		Month:       int32(order.Month),
+		TotalAmount: order.TotalAmount,
+		Status:      int32(order.Status),
+		Remark:      order.Remark,
+		UpdatedAt:   time.Now(),
+	}
+	t := query.AfdianOrderModel
+	if err := t.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: t.OutTradeNo.ColumnName().String()}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			t.Status.ColumnName().String(),
+			t.Remark.ColumnName().String(),
+			t.UpdatedAt.ColumnName().String(),
+		}),
+	}).Create(saved); err != nil {
+		return nil, fmt.Errorf("save_afdian_order_error||err=%v", err)
+	}
+	return saved, nil
+}
+
+// claimAfdianOrder 只在订单尚未邀请时写入 invited_at，返回是否由本次占用
+func claimAfdianOrder(ctx context.Context, id int64) (bool, error) {
+	t := query.AfdianOrderModel
+	info, err := t.WithContext(ctx).
+		Where(t.ID.Eq(id), t.InvitedAt.Lte(epochFloor)).
+		UpdateColumnSimple(t.InvitedAt.Value(time.Now()))
+	if err != nil {
+		return false, err
+	}
+	return info.RowsAffected == 1, nil
+}
+
+func releaseAfdianOrder(ctx context.Context, id int64) error {
+	t := query.AfdianOrderModel
+	_, err := t.WithContext(ctx).Where(t.ID.Eq(id)).UpdateColumnSimple(t.InvitedAt.Value(time.Unix(0, 0)))
+	return err
+}
+
+// findAfdianOrder 按爱发电的订单号查找；本地没有时（如 webhook 未送达）向爱发电查询并保存，订单不存在时返回 nil
+func findAfdianOrder(ctx context.Context, outTradeNo string) (*model.AfdianOrderModel, error) {
+	t := query.AfdianOrderModel
+	saved, err := t.WithContext(ctx).Where(t.OutTradeNo.Eq(outTradeNo)).First()
+	if err == nil {
+		return saved, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("find_afdian_order_error||err=%v", err)
+	}
+	order, err := afdianClient.QueryOrder(ctx, outTradeNo)
+	if err != nil || order == nil {
+		return nil, err
+	}
+	return saveAfdianOrder(ctx, order)
+}
+
+// parseAfdianRemark 从订单留言中取出 GitHub 用户名和可选的邮箱，
+// 支持 github.com/<用户名>、"GitHub: <用户名>"，以及只写了用户名的留言
+func parseAfdianRemark(remark string) (username, email string) {
+	email = afdianEmailPattern.FindString(remark)
+	rest := strings.TrimSpace(strings.Replace(remark, email, "", 1))
+	if m := afdianGithubURLPattern.FindStringSubmatch(rest); m != nil {
+		return m[1], email
+	}
+	if m := afdianGithubLabelPattern.FindStringSubmatch(rest); m != nil {
+		return m[1], email
+	}
+	if fields := strings.Fields(rest); len(fields) == 1 {
+		return strings.Trim(fields[0], "@,，;；"), email
+	}
+	return rest, email
+}
+
+// afdianSource 一笔爱发电订单，走与表格行相同的邀请流程
+type afdianSource struct {
+	order *model.AfdianOrderModel
+}
+
+func (s afdianSource) Name() string   { return SourceAfdian }
+func (s afdianSource) Detail() string { return s.order.OutTradeNo }
+func (s afdianSource) Each(ctx context.Context, fn func(context.Context, []Range, []InvalidRow) error) error {
+	username, email := parseAfdianRemark(s.order.Remark)
+	data, bad, ok := validateRow(rawRow{
+		OrderSource:    OrderSourceAfdian,
+		OrderID:        s.order.ID,
+		GithubUsername: username,
+		GithubEmail:    email,
+	})
+	var (
+		r       []Range
+		invalid []InvalidRow
+	)
+	if bad != nil {
+		invalid = append(invalid, *bad)
+	}
+	if ok {
+		r = append(r, data)
+	}
+	return fn(ctx, r, invalid)
+}
+
+// afdianWebhookHandler 爱发电 webhook；未配置爱发电时返回 nil，不注册回调地址
+func afdianWebhookHandler() http.HandlerFunc {
+	if afdianClient == nil {
+		return nil
+	}
+	return afdianWebhook
+}
+
+// afdianWebhook 验签并向爱发电确认订单后，把买家放入邀请流程；
+// 爱发电在返回 ec 不为 200 时会重试，只有可重试的错误才这样返回
+func afdianWebhook(w http.ResponseWriter, r *http.Request) {
+	reply := func(statusCode, ec int, em string) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		_ = json.NewEncoder(w).Encode(map[string]any{"ec": ec, "em": em})
+	}
+	if r.Method != http.MethodPost {
+		reply(http.StatusMethodNotAllowed, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	var payload AfdianWebhook
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAfdianWebhookSize)).Decode(&payload); err != nil {
+		reply(http.StatusBadRequest, http.StatusBadRequest, fmt.Sprintf("bind request error, err=%v", err))
+		return
+	}
+	order := payload.Data.Order
+	logger := logrus.WithFields(logrus.Fields{
+		"outTradeNo": order.OutTradeNo,
+		"planID":     order.PlanID,
+		"status":     order.Status,
+	})
+	if payload.Data.Type != "order" {
+		logger.WithField("type", payload.Data.Type).Info("afdian_webhook_ignored")
+		reply(http.StatusOK, afdianCodeOK, "")
+		return
+	}
+	if err := afdianClient.verifySign(order, payload.Data.Sign); err != nil {
+		logger.WithError(err).Warn("afdian_webhook_invalid_sign")
+		reply(http.StatusForbidden, http.StatusForbidden, "invalid sign")
+		return
+	}
+
+	confirmed, err := afdianClient.QueryOrder(r.Context(), order.OutTradeNo)
+	if err != nil {
+		logger.WithError(err).Error("afdian_query_order_error")
+		reply(http.StatusBadGateway, http.StatusBadGateway, "query order error")
+		return
+	}
+	if confirmed == nil {
+		logger.Warn("afdian_order_not_found")
+		reply(http.StatusBadRequest, http.StatusBadRequest, "order not found")
+		return
+	}
+	saved, err := saveAfdianOrder(r.Context(), confirmed)
+	if err != nil {
+		logger.WithError(err).Error("_db_save_afdian_order_error")
+		reply(http.StatusInternalServerError, http.StatusInternalServerError, "save order error")
+		return
+	}
+	if confirmed.Status != afdianOrderStatusPaid {
+		logger.Info("afdian_order_not_paid")
+		reply(http.StatusOK, afdianCodeOK, "")
+		return
+	}
+
+	// 爱发电会重复推送同一订单，并发的推送也只有一个能占用订单开始邀请
+	claimed, err := claimAfdianOrder(r.Context(), saved.ID)
+	if err != nil {
+		logger.WithError(err).Error("_db_claim_afdian_order_error")
+		reply(http.StatusInternalServerError, http.StatusInternalServerError, "claim order error")
+		return
+	}
+	if !claimed {
+		logger.Info("afdian_order_already_invited")
+		reply(http.StatusOK, afdianCodeOK, "")
+		return
+	}
+
+	// 邀请可能较慢，先回复爱发电，避免超时重试
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+		src := afdianSource{order: saved}
+		run, err := startBatch(ctx, src, false)
+		if err != nil {
+			// 批次没有创建，释放订单，等爱发电下一次推送
+			logger.WithError(err).Error("afdian_invite_error")
+			if err = releaseAfdianOrder(ctx, saved.ID); err != nil {
+				logger.WithError(err).Error("_db_release_afdian_order_error")
+			}
+			return
+		}
+		if err = run.execute(ctx, src); err != nil {
+			logger.WithError(err).Error("afdian_invite_error")
+			return
+		}
+		logger.WithField("result", run.response()).Info("afdian_invite_done")
+	}()
+	reply(http.StatusOK, afdianCodeOK, "")
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"net/http"
+)
+
+//go:embed api/openapi.json
+var openAPISpec []byte
+
+// apiV1Key 请求经由 /api/v1 路由时在 context 中的标记
+type apiV1Key struct{}
+
+// APIError /api/v1 的错误对象，Code 由状态码决定，见 errorCode
+type APIError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Details any    `json:"details,omitempty"`
+}
+
+type errorEnvelope struct {
+	Error APIError `json:"error"`
+}
+
+type dataEnvelope struct {
+	Data any `json:"data"`
+}
+
+// registerAPIv1 /api/v1 路由，与旧路由共用同一套 handler，只有响应格式不同：
+// 成功为 {"data": ...}，失败为 {"error": {"code", "message"}}
+func registerAPIv1(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/v1/openapi.json", serveOpenAPISpec)
+	mux.HandleFunc("POST /api/v1/jobs", v1(requireRole(RoleOperator, invite)))
+	mux.HandleFunc("GET /api/v1/jobs/{id}", v1(requireRole(RoleViewer, getJob)))
+	// 事件流的响应为 text/event-stream，只有连接建立前的错误使用错误对象
+	mux.HandleFunc("GET /api/v1/jobs/{id}/events", v1(requireRole(RoleViewer, jobEventStream)))
+	mux.HandleFunc("POST /api/v1/imports", v1(requireRole(RoleOperator, imports)))
+	mux.HandleFunc("GET /api/v1/invitations", v1(requireRole(RoleViewer, findInvitations)))
+	mux.HandleFunc("POST /api/v1/invitations", v1(requireRole(RoleOperator, createInvitation)))
+	mux.HandleFunc("GET /api/v1/invitations/{id}", v1(requireRole(RoleViewer, getInvitation)))
+	mux.HandleFunc("POST /api/v1/invitations/retry", v1(requireRole(RoleOperator, retryFailed)))
+	mux.HandleFunc("POST /api/v1/invitations/{id}/revoke", v1(requireRole(RoleOperator, revokeInvitationHandler)))
+	mux.HandleFunc("GET /api/v1/successes", v1(requireRole(RoleViewer, success)))
+	mux.HandleFunc("GET /api/v1/failures", v1(requireRole(RoleViewer, failed)))
+	mux.HandleFunc("GET /api/v1/reports/abuse", v1(requireRole(RoleViewer, abuseReport)))
+	// 导出的响应是文件本身，只有错误使用错误对象
+	mux.HandleFunc("GET /api/v1/exports/invitations", v1(requireRole(RoleViewer, exportInvitations)))
+	mux.HandleFunc("GET /api/v1/access-rules", v1(requireRole(RoleOperator, listAccessRules)))
+	mux.HandleFunc("POST /api/v1/access-rules", v1(requireRole(RoleAdmin, createAccessRule)))
+	mux.HandleFunc("PATCH /api/v1/access-rules/{id}", v1(requireRole(RoleAdmin, updateAccessRule)))
+	mux.HandleFunc("DELETE /api/v1/access-rules/{id}", v1(requireRole(RoleAdmin, deleteAccessRule)))
+}
+
+func v1(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		next(w, r.WithContext(context.WithValue(r.Context(), apiV1Key{}, true)))
+	}
+}
+
+func isV1(r *http.Request) bool {
+	v, _ := r.Context().Value(apiV1Key{}).(bool)
+	return v
+}
+
+func errorCode(statusCode int) string {
+	switch statusCode {
+	case http.StatusBadRequest:
+		return "bad_request"
+	case http.StatusUnauthorized:
+		return "unauthorized"
+	case http.StatusForbidden:
+		return "forbidden"
+	case http.StatusNotFound:
+		return "not_found"
+	case http.StatusMethodNotAllowed:
+		return "method_not_allowed"
+	case http.StatusConflict:
+		return "conflict"
+	case http.StatusRequestEntityTooLarge:
+		return "too_large"
+	case http.StatusTooManyRequests:
+		return "rate_limited"
+	case http.StatusBadGateway:
+		return "bad_gateway"
+	}
+	return "internal"
+}
+
+// writeError 旧路由输出纯文本，/api/v1 输出错误对象
+func writeError(w http.ResponseWriter, r *http.Request, statusCode int, err error) {
+	writeErrorDetails(w, r, statusCode, err, nil)
+}
+
+func writeErrorDetails(w http.ResponseWriter, r *http.Request, statusCode int, err error, details any) {
+	if !isV1(r) {
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+	writeBody(w, statusCode, errorEnvelope{Error: APIError{Code: errorCode(statusCode), Message: err.Error(), Details: details}})
+}
+
+// writeJSON 旧路由直接输出 v，/api/v1 包在 data 中
+func writeJSON(w http.ResponseWriter, r *http.Request, statusCode int, v any) {
+	if isV1(r) {
+		v = dataEnvelope{Data: v}
+	}
+	writeBody(w, statusCode, v)
+}
+
+func writeBody(w http.ResponseWriter, statusCode int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func serveOpenAPISpec(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(openAPISpec)
+}
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/model"
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/query"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gen"
+	"gorm.io/gorm"
+)
+
+const (
+	RoleViewer   = "viewer"
+	RoleOperator = "operator"
+	RoleAdmin    = "admin"
+
+	apiKeyPrefix = "oib_"
+	// 定时任务调用自身接口时使用的 key ID
+	internalKeyID = "internal"
+	// last_used_at 最多每隔这么久写一次，避免每个请求都写数据库
+	apiKeyTouchInterval = time.Minute
+)
+
+// publicPaths 不需要认证的路由，按路径精确匹配；其余路由都由 authMiddleware 认证，新加的路由默认需要 API key
+var publicPaths = []string{
+	// 存活、就绪探针
+	"/healthz",
+	"/readyz",
+	// 飞书卡片回调和爱发电 webhook 自带签名校验
+	"/feishu/card",
+	"/webhooks/afdian",
+	// 买家自助领取页面，核验订单并限流
+	"/claim",
+	"/api/v1/openapi.json",
+	// 管理后台的登录、登出，其余 /admin/ 页面使用登录后的会话
+	"/admin",
+	"/admin/login",
+	"/admin/logout",
+}
+
+// apiKeyContextKey 通过认证的 *APIKey 在 context 中的 key
+type apiKeyContextKey struct{}
+
+// roles 按权限从低到高排列，高权限包含低权限
+var roles = []string{RoleViewer, RoleOperator, RoleAdmin}
+
+// internalAPIKey 进程启动时随机生成，只在内存中，供定时任务调用 /invite
+var internalAPIKey = newAPIKey()
+
+// APIKey 通过认证的调用方
+type APIKey struct {
+	ID   string
+	Name string
+	Role string
+}
+
+func (k *APIKey) allows(role string) bool {
+	return slices.Index(roles, k.Role) >= slices.Index(roles, role)
+}
+
+func newAPIKey() string {
+	return apiKeyPrefix + randomToken()
+}
+
+// randomToken 32 字节随机数，用于 API key 和管理后台的会话
+func randomToken() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// requestAPIKey 从 Authorization: Bearer 或 X-API-Key 头中取出 key
+func requestAPIKey(r *http.Request) string {
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+	}
+	return strings.TrimSpace(r.Header.Get("X-API-Key"))
+}
+
+func authenticate(ctx context.Context, raw string) (*APIKey, error) {
+	if raw == "" {
+		return nil, errors.New("missing api key")
+	}
+	if subtle.ConstantTimeCompare([]byte(raw), []byte(internalAPIKey)) == 1 {
+		return &APIKey{ID: internalKeyID, Name: internalKeyID, Role: RoleOperator}, nil
+	}
+	t := query.APIKeyModel
+	return findAPIKey(ctx, t.KeyHash.Eq(hashAPIKey(raw)))
+}
+
+// authenticateKeyID 按 key ID 重新校验，管理后台的会话用它确认 key 仍然有效
+func authenticateKeyID(ctx context.Context, id string) (*APIKey, error) {
+	if id == internalKeyID {
+		return &APIKey{ID: internalKeyID, Name: internalKeyID, Role: RoleOperator}, nil
+	}
+	t := query.APIKeyModel
+	return findAPIKey(ctx, t.ID.Eq(id))
+}
+
+func findAPIKey(ctx context.Context, cond gen.Condition) (*APIKey, error) {
+	t := query.APIKeyModel
+	record, err := t.WithContext(ctx).Where(cond).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("invalid api key")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find_api_key_error||err=%v", err)
+	}
+	if !isUnset(record.RevokedAt) {
+		return nil, errors.New("api key revoked")
+	}
+	if now := time.Now(); now.Sub(record.LastUsedAt) >= apiKeyTouchInterval {
+		// 并发请求中只有一个会写入
+		if _, err = t.WithContext(ctx).
+			Where(t.ID.Eq(record.ID), t.LastUsedAt.Lt(now.Add(-apiKeyTouchInterval))).
+			UpdateColumnSimple(t.LastUsedAt.Value(now)); err != nil {
+			logrus.WithError(err).WithField("keyID", record.ID).Error("_db_update_api_key_error")
+		}
+	}
+	return &APIKey{ID: record.ID, Name: record.Name, Role: record.Role}, nil
+}
+
+func requestLogger(r *http.Request) *logrus.Entry {
+	return logrus.WithFields(logrus.Fields{
+		"method": r.Method,
+		"path":   r.URL.Path,
+		"remote": r.RemoteAddr,
+	})
+}
+
+// authMiddleware 在 mux 外层统一认证：publicPaths 直接放行，/admin/ 使用管理后台的会话，
+// 其余请求校验 API key 并把 *APIKey 放入 context，角色由路由上的 requireRole 检查
+func authMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if slices.Contains(publicPaths, r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/admin/") {
+			authenticateDashboard(w, r, next)
+			return
+		}
+		key, err := authenticate(r.Context(), requestAPIKey(r))
+		if err != nil {
+			requestLogger(r).WithError(err).Warn("api_unauthorized")
+			if strings.HasPrefix(r.URL.Path, "/api/v1/") {
+				r = r.WithContext(context.WithValue(r.Context(), apiV1Key{}, true))
+			}
+			writeError(w, r, http.StatusUnauthorized, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), apiKeyContextKey{}, key)))
+	})
+}
+
+// requireRole 要求 authMiddleware 认证的 key 至少具有 role 权限，每个请求都记录使用的 key ID
+func requireRole(role string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := requestLogger(r)
+		key, ok := r.Context().Value(apiKeyContextKey{}).(*APIKey)
+		if !ok {
+			// 路由被误加进 publicPaths 时拒绝请求，而不是放行
+			logger.Error("api_key_missing_in_context")
+			writeError(w, r, http.StatusUnauthorized, errors.New("missing api key"))
+			return
+		}
+		logger = logger.WithFields(logrus.Fields{"keyID": key.ID, "keyName": key.Name, "role": key.Role})
+		if !key.allows(role) {
+			logger.WithField("required", role).Warn("api_forbidden")
+			writeError(w, r, http.StatusForbidden, fmt.Errorf("role %s required", role))
+			return
+		}
+		logger.Info("api_request")
+		next(w, r)
+	}
+}
+
+// runAPIKeyCommand 管理 API key：
+//
+//	apikey create -name <名称> -role viewer|operator|admin
+//	apikey list
+//	apikey revoke <key ID>
+func runAPIKeyCommand(ctx context.Context, args []string) error {
+	usage := errors.New("usage: apikey create -name <name> -role viewer|operator|admin | apikey list | apikey revoke <id>")
+	if len(args) == 0 {
+		return usage
+	}
+	t := query.APIKeyModel
+	switch args[0] {
+	case "create":
+		fs := flag.NewFlagSet("apikey create", flag.ContinueOnError)
+		name := fs.String("name", "", "who or what uses the key")
+		role := fs.String("role", RoleViewer, "viewer, operator or admin")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *name == "" {
+			return errors.New("-name is required")
+		}
+		if !slices.Contains(roles, *role) {
+			return fmt.Errorf("unknown role %q", *role)
+		}
+		raw := newAPIKey()
+		record := &model.APIKeyModel{
+			ID:      uuid.New().String(),
+			Name:    *name,
+			Prefix:  raw[:len(apiKeyPrefix)+6],
+			KeyHash: hashAPIKey(raw),
+			Role:    *role,
+		}
+		if err := t.WithContext(ctx).Create(record); err != nil {
+			return fmt.Errorf("create api key error||err=%w", err)
+		}
+		fmt.Printf("id:   %s\nrole: %s\nkey:  %s\n\nthe key is shown only once\n", record.ID, record.Role, raw)
+	case "list":
+		keys, err := t.WithContext(ctx).Order(t.CreatedAt).Find()
+		if err != nil {
+			return fmt.Errorf("list api keys error||err=%w", err)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tROLE\tPREFIX\tCREATED\tLAST USED\tREVOKED")
+		for _, k := range keys {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				k.ID, k.Name, k.Role, k.Prefix, k.CreatedAt.Format(time.DateTime), formatOptionalTime(k.LastUsedAt), formatOptionalTime(k.RevokedAt))
+		}
+		return w.Flush()
+	case "revoke":
+		if len(args) != 2 {
+			return usage
+		}
+		info, err := t.WithContext(ctx).
+			Where(t.ID.Eq(args[1])).
+			UpdateColumnSimple(t.RevokedAt.Value(time.Now()))
+		if err != nil {
+			return fmt.Errorf("revoke api key error||err=%w", err)
+		}
+		if info.RowsAffected == 0 {
+			return fmt.Errorf("api key %s not found", args[1])
+		}
+		fmt.Printf("revoked %s\n", args[1])
+	default:
+		return usage
+	}
+	return nil
+}
+
+// formatOptionalTime epoch 默认值显示为 -
+func formatOptionalTime(t time.Time) string {
+	if isUnset(t) {
+		return "-"
+	}
+	return t.Format(time.DateTime)
+}
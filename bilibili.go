@@ -1,5 +1,278 @@
 package main
 
-func purchase(orderID int64) bool {
-	return true
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/model"
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/query"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	VerifierMall = "mall"
+	VerifierNone = "none"
+
+	// 会员购订单详情接口中订单不存在时的返回码
+	mallCodeOrderNotFound = -404
+	mallPayStatusPaid     = 1
+	mallRefundStatusNone  = 0
+)
+
+var (
+	ErrNotPurchased = errors.New("not purchased")
+
+	bilibiliMallCookie string
+	purchaseVerifier   PurchaseVerifier = noneVerifier{}
+)
+
+// Purchase 订单核验结果
+type Purchase struct {
+	// Source 订单来源，见 OrderSourceBilibili、OrderSourceAfdian
+	Source    string
+	OrderID   int64
+	Exists    bool
+	Paid      bool
+	Refunded  bool
+	ProductID int64
+	// PlanID 爱发电的方案 ID
+	PlanID string
+	Status string
+}
+
+// productKey 会员购为商品 ID，爱发电为方案 ID
+func (p *Purchase) productKey() string {
+	if p.Source == OrderSourceAfdian {
+		return strings.ToLower(p.PlanID)
+	}
+	return strconv.FormatInt(p.ProductID, 10)
+}
+
+// productConfig 商品配置：会员购为 bilibili.products.<商品 ID>，爱发电为 afdian.plans.<方案 ID>
+func (p *Purchase) productConfig() map[string]any {
+	key := "bilibili.products"
+	if p.Source == OrderSourceAfdian {
+		key = "afdian.plans"
+	}
+	return cast.ToStringMap(viper.GetStringMap(key)[p.productKey()])
+}
+
+// Check 订单存在、已支付、未退款，且商品在 products 中（为空时不限制商品）
+func (p *Purchase) Check(products []string) error {
+	switch {
+	case !p.Exists:
+		return fmt.Errorf("%w: order %d not found", ErrNotPurchased, p.OrderID)
+	case !p.Paid:
+		return fmt.Errorf("%w: order %d not paid, status=%s", ErrNotPurchased, p.OrderID, p.Status)
+	case p.Refunded:
+		return fmt.Errorf("%w: order %d refunded", ErrNotPurchased, p.OrderID)
+	case len(products) > 0 && !slices.Contains(products, p.productKey()):
+		return fmt.Errorf("%w: order %d product %s not expected", ErrNotPurchased, p.OrderID, p.productKey())
+	}
+	return nil
+}
+
+// PurchaseVerifier 核验订单是否真实购买
+type PurchaseVerifier interface {
+	Verify(ctx context.Context, orderID int64) (*Purchase, error)
+}
+
+// setupPurchaseVerifier 按 bilibili.verifier 选择核验方式，并在外层加上 Postgres 缓存；
+// 未配置时沿用原来的行为，不核验订单，会员购核验需显式设置为 mall
+func setupPurchaseVerifier() error {
+	switch kind := viper.GetString("bilibili.verifier"); kind {
+	case VerifierMall:
+		if bilibiliMallCookie == "" {
+			return fmt.Errorf("env '%s' not exist", EnvBilibiliMallCookie)
+		}
+		baseURL := viper.GetString("bilibili.mall.base_url")
+		if baseURL == "" {
+			baseURL = "https://mall.bilibili.com"
+		}
+		purchaseVerifier = &cachedVerifier{
+			next:       &mallVerifier{baseURL: strings.TrimRight(baseURL, "/"), cookie: bilibiliMallCookie},
+			validTTL:   durationOr(viper.GetDuration("bilibili.cache.valid_ttl"), 24*time.Hour),
+			invalidTTL: durationOr(viper.GetDuration("bilibili.cache.invalid_ttl"), 10*time.Minute),
+		}
+	case "", VerifierNone:
+		logrus.Warn("bilibili.verifier is none, every order is treated as purchased")
+		purchaseVerifier = noneVerifier{}
+	default:
+		return fmt.Errorf("unknown bilibili.verifier %q", kind)
+	}
+	return nil
+}
+
+func durationOr(d, fallback time.Duration) time.Duration {
+	if d <= 0 {
+		return fallback
+	}
+	return d
+}
+
+// expectedProducts 允许的商品：bilibili.mall.product_ids 或 afdian.plan_ids
+func expectedProducts(source string) []string {
+	if source == OrderSourceAfdian {
+		return viper.GetStringSlice("afdian.plan_ids")
+	}
+	return viper.GetStringSlice("bilibili.mall.product_ids")
+}
+
+// purchase 按订单来源核验订单，未购买时返回包装了 ErrNotPurchased 的错误
+func purchase(ctx context.Context, source string, orderID int64) (*Purchase, error) {
+	verifier := purchaseVerifier
+	if source == OrderSourceAfdian {
+		if afdianVerifier == nil {
+			return nil, fmt.Errorf("afdian not configured||orderID=%d", orderID)
+		}
+		verifier = afdianVerifier
+	}
+	p, err := verifier.Verify(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("verify purchase error||orderID=%d||err=%w", orderID, err)
+	}
+	p.Source = source
+	if err = p.Check(expectedProducts(source)); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+type noneVerifier struct{}
+
+func (noneVerifier) Verify(_ context.Context, orderID int64) (*Purchase, error) {
+	return &Purchase{OrderID: orderID, Exists: true, Paid: true, Status: VerifierNone}, nil
+}
+
+// mallVerifier 通过会员购（工房）商家后台的订单详情接口核验
+// mallHTTPClient 每个邀请都要等核验结果，不能无限等待会员购
+var mallHTTPClient = newInstrumentedClient(upstreamMall, 10*time.Second, plainEndpoint)
+
+type mallVerifier struct {
+	baseURL string
+	cookie  string
+}
+
+// MallOrderResponse 会员购订单详情接口的返回
+type MallOrderResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    *struct {
+		OrderID      int64  `json:"orderId"`
+		OrderStatus  int    `json:"orderStatus"`
+		StatusName   string `json:"statusName"`
+		PayStatus    int    `json:"payStatus"`
+		RefundStatus int    `json:"refundStatus"`
+		ItemsID      int64  `json:"itemsId"`
+	} `json:"data"`
+}
+
+func (v *mallVerifier) Verify(ctx context.Context, orderID int64) (*Purchase, error) {
+	fullURL := fmt.Sprintf("%s/mall-seller/order/detail?%s", v.baseURL, url.Values{
+		"orderId": []string{strconv.FormatInt(orderID, 10)},
+	}.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Cookie", v.cookie)
+
+	resp, err := mallHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("do request error||err=%w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read body error||err=%w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status||code=%d||resp=%s", resp.StatusCode, string(body))
+	}
+
+	var r MallOrderResponse
+	if err = json.Unmarshal(body, &r); err != nil {
+		return nil, fmt.Errorf("bind response error||resp=%s||err=%w", string(body), err)
+	}
+	p := &Purchase{OrderID: orderID}
+	if r.Code == mallCodeOrderNotFound || (r.Code == 0 && r.Data == nil) {
+		return p, nil
+	}
+	if r.Code != 0 {
+		return nil, fmt.Errorf("response code non-zero||resp=%s", string(body))
+	}
+	p.Exists = true
+	p.Paid = r.Data.PayStatus == mallPayStatusPaid
+	p.Refunded = r.Data.RefundStatus != mallRefundStatusNone
+	p.ProductID = r.Data.ItemsID
+	p.Status = r.Data.StatusName
+	return p, nil
+}
+
+// cachedVerifier 把核验结果缓存在 purchase_verifications 表中；
+// 有效订单缓存较久，无效订单很快过期，便于买家付款后重新核验
+type cachedVerifier struct {
+	next       PurchaseVerifier
+	validTTL   time.Duration
+	invalidTTL time.Duration
+}
+
+func (v *cachedVerifier) Verify(ctx context.Context, orderID int64) (*Purchase, error) {
+	cached, err := query.PurchaseVerificationModel.WithContext(ctx).
+		Where(query.PurchaseVerificationModel.OrderID.Eq(orderID)).
+		First()
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		logrus.WithError(err).WithField("orderID", orderID).Error("_db_find_purchase_error")
+	}
+	if cached != nil {
+		p := &Purchase{
+			OrderID:   cached.OrderID,
+			Exists:    cached.Found,
+			Paid:      cached.Paid,
+			Refunded:  cached.Refunded,
+			ProductID: cached.ProductID,
+			Status:    cached.Status,
+		}
+		ttl := v.invalidTTL
+		if p.Check(nil) == nil {
+			ttl = v.validTTL
+		}
+		if time.Since(cached.VerifiedAt) < ttl {
+			return p, nil
+		}
+	}
+
+	p, err := v.next.Verify(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	record := &model.PurchaseVerificationModel{
+		OrderID:    p.OrderID,
+		Found:      p.Exists,
+		Paid:       p.Paid,
+		Refunded:   p.Refunded,
+		ProductID:  p.ProductID,
+		Status:     p.Status,
+		VerifiedAt: time.Now(),
+	}
+	if err = query.PurchaseVerificationModel.WithContext(ctx).
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		Create(record); err != nil {
+		logrus.WithError(err).WithField("orderID", orderID).Error("_db_save_purchase_error")
+	}
+	return p, nil
 }
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Nicknamezz00/org-invitation-autobot/fake"
+	"github.com/spf13/viper"
+)
+
+func TestMallVerifier(t *testing.T) {
+	mall := fake.NewMall(
+		fake.MallOrder{OrderID: 1, StatusName: "已完成", PayStatus: fake.MallPayStatusPaid, ItemsID: 100},
+		fake.MallOrder{OrderID: 2, StatusName: "待付款", PayStatus: fake.MallPayStatusUnpaid, ItemsID: 100},
+		fake.MallOrder{OrderID: 3, StatusName: "已退款", PayStatus: fake.MallPayStatusPaid, RefundStatus: fake.MallRefundStatusRefunded, ItemsID: 100},
+		fake.MallOrder{OrderID: 4, StatusName: "已完成", PayStatus: fake.MallPayStatusPaid, ItemsID: 200},
+	)
+	mall.Cookie = "SESSDATA=ok"
+	srv := httptest.NewServer(mall)
+	defer srv.Close()
+
+	v := &mallVerifier{baseURL: srv.URL, cookie: mall.Cookie}
+	products := []string{"100"}
+	tests := []struct {
+		name     string
+		orderID  int64
+		exists   bool
+		purchase bool
+	}{
+		{name: "paid", orderID: 1, exists: true, purchase: true},
+		{name: "unpaid", orderID: 2, exists: true},
+		{name: "refunded", orderID: 3, exists: true},
+		{name: "other product", orderID: 4, exists: true},
+		{name: "not found", orderID: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := v.Verify(context.Background(), tt.orderID)
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if p.Exists != tt.exists {
+				t.Errorf("Exists = %v, want %v", p.Exists, tt.exists)
+			}
+			if err = p.Check(products); (err == nil) != tt.purchase {
+				t.Errorf("Check() error = %v, want purchased %v", err, tt.purchase)
+			}
+		})
+	}
+}
+
+func TestMallVerifierBadCookie(t *testing.T) {
+	mall := fake.NewMall(fake.MallOrder{OrderID: 1, PayStatus: fake.MallPayStatusPaid})
+	mall.Cookie = "SESSDATA=ok"
+	srv := httptest.NewServer(mall)
+	defer srv.Close()
+
+	v := &mallVerifier{baseURL: srv.URL, cookie: "SESSDATA=expired"}
+	if p, err := v.Verify(context.Background(), 1); err == nil {
+		t.Fatalf("Verify() = %+v, want error for a rejected cookie", p)
+	}
+}
+
+func TestMallVerifierPaymentChanges(t *testing.T) {
+	mall := fake.NewMall(fake.MallOrder{OrderID: 1, PayStatus: fake.MallPayStatusUnpaid})
+	srv := httptest.NewServer(mall)
+	defer srv.Close()
+
+	v := &mallVerifier{baseURL: srv.URL}
+	for _, o := range []struct {
+		order    fake.MallOrder
+		purchase bool
+	}{
+		{order: fake.MallOrder{OrderID: 1, PayStatus: fake.MallPayStatusUnpaid}},
+		{order: fake.MallOrder{OrderID: 1, PayStatus: fake.MallPayStatusPaid}, purchase: true},
+		{order: fake.MallOrder{OrderID: 1, PayStatus: fake.MallPayStatusPaid, RefundStatus: fake.MallRefundStatusRefunded}},
+	} {
+		mall.Put(o.order)
+		p, err := v.Verify(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("Verify() error = %v", err)
+		}
+		if err = p.Check(nil); (err == nil) != o.purchase {
+			t.Errorf("order %+v: Check() error = %v, want purchased %v", o.order, err, o.purchase)
+		}
+	}
+}
+
+func TestSetupPurchaseVerifier(t *testing.T) {
+	defer func(v PurchaseVerifier, cookie string) {
+		purchaseVerifier, bilibiliMallCookie = v, cookie
+		viper.Set("bilibili.verifier", nil)
+	}(purchaseVerifier, bilibiliMallCookie)
+
+	viper.Set("bilibili.verifier", "")
+	if err := setupPurchaseVerifier(); err != nil {
+		t.Fatalf("setupPurchaseVerifier() error = %v", err)
+	}
+	if _, ok := purchaseVerifier.(noneVerifier); !ok {
+		t.Errorf("default verifier = %T, want noneVerifier", purchaseVerifier)
+	}
+
+	viper.Set("bilibili.verifier", VerifierMall)
+	bilibiliMallCookie = ""
+	if err := setupPurchaseVerifier(); err == nil {
+		t.Error("setupPurchaseVerifier() with mall and no cookie, want error")
+	}
+
+	bilibiliMallCookie = "SESSDATA=ok"
+	if err := setupPurchaseVerifier(); err != nil {
+		t.Fatalf("setupPurchaseVerifier() error = %v", err)
+	}
+	if _, ok := purchaseVerifier.(*cachedVerifier); !ok {
+		t.Errorf("mall verifier = %T, want *cachedVerifier", purchaseVerifier)
+	}
+}
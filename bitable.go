@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+	larkbitable "github.com/larksuite/oapi-sdk-go/v3/service/bitable/v1"
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+)
+
+const bitablePageSize = 500
+
+// BitableConfig 多维表格数据源配置，对应 config.yaml 中的 feishu.bitable
+type BitableConfig struct {
+	AppToken string `mapstructure:"app_token"`
+	TableID  string `mapstructure:"table_id"`
+	ViewID   string `mapstructure:"view_id"`
+	Filter   string `mapstructure:"filter"`
+	Fields   struct {
+		OrderID        string `mapstructure:"order_id"`
+		GithubUsername string `mapstructure:"github_username"`
+		GithubEmail    string `mapstructure:"github_email"`
+		Status         string `mapstructure:"status"`
+		Error          string `mapstructure:"error"`
+		// ExpiresAt 可选的到期时间字段，文本或日期字段均可
+		ExpiresAt string `mapstructure:"expires_at"`
+	} `mapstructure:"fields"`
+}
+
+func (c BitableConfig) Enabled() bool {
+	return c.AppToken != "" && c.TableID != ""
+}
+
+func bitableConfig() (c BitableConfig, err error) {
+	sub := viper.Sub("feishu.bitable")
+	if sub == nil {
+		return c, errors.New("feishu.bitable not configured")
+	}
+	if err = sub.Unmarshal(&c); err != nil {
+		return c, fmt.Errorf("unmarshal bitable config error||err=%w", err)
+	}
+	if !c.Enabled() {
+		return c, errors.New("feishu.bitable.app_token or table_id is empty")
+	}
+	if c.Fields.OrderID == "" || c.Fields.GithubUsername == "" || c.Fields.GithubEmail == "" {
+		return c, fmt.Errorf("feishu.bitable.fields incomplete||fields=%+v", c.Fields)
+	}
+	return c, nil
+}
+
+// BitableEach 分页列出多维表格中的记录，按配置的字段名映射为 Range，每页交给 fn 处理
+func BitableEach(ctx context.Context, fn func(context.Context, []Range, []InvalidRow) error) error {
+	conf, err := bitableConfig()
+	if err != nil {
+		return err
+	}
+	client := newFeishuClient()
+
+	var pageToken string
+	for {
+		// 每页之间可能处理很久，每次请求前重新取 token，过期前会自动换新
+		feishuTenantAccessToken, err := acquireFeishuTenantAccessToken()
+		if err != nil {
+			return fmt.Errorf("error acquiring tenant access token||err=%w", err)
+		}
+		opts := larkcore.WithTenantAccessToken(feishuTenantAccessToken)
+		builder := larkbitable.NewListAppTableRecordReqBuilder().
+			AppToken(conf.AppToken).
+			TableId(conf.TableID).
+			PageSize(bitablePageSize)
+		if conf.ViewID != "" {
+			builder.ViewId(conf.ViewID)
+		}
+		if conf.Filter != "" {
+			builder.Filter(conf.Filter)
+		}
+		if pageToken != "" {
+			builder.PageToken(pageToken)
+		}
+		resp, err := client.Bitable.V1.AppTableRecord.List(ctx, builder.Build(), opts)
+		if err != nil {
+			return fmt.Errorf("list bitable records error||err=%w", err)
+		}
+		if !resp.Success() {
+			return fmt.Errorf("logId: %s, error response: \n%s", resp.RequestId(), larkcore.Prettify(resp.CodeError))
+		}
+		if resp.Data == nil {
+			break
+		}
+		var (
+			r       []Range
+			invalid []InvalidRow
+		)
+		for _, item := range resp.Data.Items {
+			data, bad, ok := validateRow(parseBitableRecord(conf, item))
+			if bad != nil {
+				invalid = append(invalid, *bad)
+			}
+			if ok {
+				r = append(r, data)
+			}
+		}
+		if err = fn(ctx, r, invalid); err != nil {
+			return err
+		}
+		if resp.Data.HasMore == nil || !*resp.Data.HasMore || resp.Data.PageToken == nil {
+			break
+		}
+		pageToken = *resp.Data.PageToken
+	}
+	return nil
+}
+
+func parseBitableRecord(conf BitableConfig, record *larkbitable.AppTableRecord) (data rawRow) {
+	if record.RecordId != nil {
+		data.RecordID = *record.RecordId
+	}
+	data.OrderID = bitableFieldText(record.Fields[conf.Fields.OrderID])
+	data.GithubUsername = bitableFieldText(record.Fields[conf.Fields.GithubUsername])
+	data.GithubEmail = bitableFieldText(record.Fields[conf.Fields.GithubEmail])
+	if conf.Fields.ExpiresAt != "" {
+		data.ExpiresAt = bitableFieldText(record.Fields[conf.Fields.ExpiresAt])
+	}
+	return data
+}
+
+// bitableFieldText 文本字段可能返回字符串或富文本分段数组，这里把分段拼接起来
+func bitableFieldText(v any) string {
+	segments, ok := v.([]interface{})
+	if !ok {
+		return cast.ToString(v)
+	}
+	var text string
+	for _, segment := range segments {
+		if m, ok := segment.(map[string]any); ok {
+			text += cast.ToString(m["text"])
+		}
+	}
+	return text
+}
+
+// BitableWriteStatus 把邀请结果回写到记录的状态字段（及可选的错误字段）
+func BitableWriteStatus(ctx context.Context, recordID, status, cause string) error {
+	conf, err := bitableConfig()
+	if err != nil {
+		return err
+	}
+	if conf.Fields.Status == "" {
+		return nil
+	}
+	fields := map[string]any{conf.Fields.Status: status}
+	if conf.Fields.Error != "" {
+		fields[conf.Fields.Error] = cause
+	}
+
+	feishuTenantAccessToken, err := acquireFeishuTenantAccessToken()
+	if err != nil {
+		return fmt.Errorf("error acquiring tenant access token||err=%w", err)
+	}
+	client := newFeishuClient()
+	req := larkbitable.NewUpdateAppTableRecordReqBuilder().
+		AppToken(conf.AppToken).
+		TableId(conf.TableID).
+		RecordId(recordID).
+		AppTableRecord(larkbitable.NewAppTableRecordBuilder().Fields(fields).Build()).
+		Build()
+	resp, err := client.Bitable.V1.AppTableRecord.Update(ctx, req, larkcore.WithTenantAccessToken(feishuTenantAccessToken))
+	if err != nil {
+		return fmt.Errorf("update bitable record error||recordID=%s||err=%w", recordID, err)
+	}
+	if !resp.Success() {
+		return fmt.Errorf("logId: %s, error response: \n%s", resp.RequestId(), larkcore.Prettify(resp.CodeError))
+	}
+	return nil
+}
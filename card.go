@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/model"
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/query"
+	"github.com/google/uuid"
+	larkcard "github.com/larksuite/oapi-sdk-go/v3/card"
+	"github.com/larksuite/oapi-sdk-go/v3/core/httpserverext"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cast"
+	"gorm.io/gorm"
+)
+
+const (
+	CardActionRetry   = "retry"
+	CardActionIgnore  = "ignore"
+	CardActionResolve = "resolve"
+)
+
+// cardActionableStatuses 卡片只在这些状态下可以操作，过期的卡片不能覆盖已处理的记录
+var cardActionableStatuses = []string{InvitationStatusFailed, InvitationStatusSuspectedAbuse}
+
+var (
+	feishuCardVerificationToken string
+	feishuCardEncryptKey        string
+)
+
+// cardActionHandler 飞书卡片回调；未配置 verification token 时返回 nil，不注册回调地址，
+// 否则 SDK 会跳过签名校验
+func cardActionHandler() http.HandlerFunc {
+	if feishuCardVerificationToken == "" {
+		logrus.Warnf("env '%s' not exist, card actions disabled", EnvFeishuCardVerificationToken)
+		return nil
+	}
+	handler := larkcard.NewCardActionHandler(feishuCardVerificationToken, feishuCardEncryptKey, handleCardAction)
+	return httpserverext.NewCardActionHandlerFunc(handler)
+}
+
+func handleCardAction(ctx context.Context, action *larkcard.CardAction) (any, error) {
+	if action.Action == nil {
+		return nil, errors.New("empty card action")
+	}
+	var (
+		kind         = cast.ToString(action.Action.Value["action"])
+		invitationID = cast.ToString(action.Action.Value["invitation_id"])
+	)
+	logger := logrus.WithFields(logrus.Fields{
+		"action":       kind,
+		"invitationID": invitationID,
+		"openID":       action.OpenID,
+		"userID":       action.UserID,
+	})
+
+	invitation, err := query.InvitationModel.WithContext(ctx).
+		Where(query.InvitationModel.ID.Eq(invitationID)).
+		First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("invitation not found||id=%s", invitationID)
+		}
+		return nil, fmt.Errorf("find_invitation_error||err=%v", err)
+	}
+
+	var status, result string
+	switch kind {
+	case CardActionRetry:
+		status, result = InvitationStatusPending, "已提交重试"
+	case CardActionIgnore:
+		status, result = InvitationStatusIgnored, "已忽略，后续运行将跳过该用户"
+	case CardActionResolve:
+		status, result = InvitationStatusResolved, "已标记为已解决"
+	default:
+		return nil, fmt.Errorf("unknown card action||action=%s", kind)
+	}
+	// 按当前状态条件更新，并发点击或过期的卡片只返回当前状态
+	changed, err := transitionInvitationStatus(ctx, invitation, cardActionableStatuses, status)
+	if err != nil {
+		return nil, err
+	}
+	if !changed {
+		logger.WithField("status", invitation.InvitationStatus).Warn("card_action_stale")
+		return handledCard(invitation, kind, fmt.Sprintf("记录当前状态为 %s，未做处理", invitation.InvitationStatus), action.OpenID), nil
+	}
+	if kind == CardActionRetry {
+		// 卡片回调需要在 3 秒内响应，邀请放到后台执行，失败会再发一张卡片；
+		// 进程在邀请完成前退出时记录停留在 PENDING，超过 stalePendingAfter 后可以再次重试
+		go retryInvitation(invitation)
+	}
+
+	audit := &model.InvitationActionModel{
+		ID:             uuid.New().String(),
+		InvitationID:   invitation.ID,
+		Action:         kind,
+		OperatorOpenID: action.OpenID,
+		OperatorUserID: action.UserID,
+	}
+	if err = query.InvitationActionModel.WithContext(ctx).Create(audit); err != nil {
+		logger.WithError(err).Error("_db_create_action_error")
+	}
+	logger.Info("card_action")
+
+	return handledCard(invitation, kind, result, action.OpenID), nil
+}
+
+func setInvitationStatus(ctx context.Context, invitation *model.InvitationModel, status string) error {
+	if _, err := query.InvitationModel.WithContext(ctx).
+		Where(query.InvitationModel.ID.Eq(invitation.ID)).
+		UpdateColumnSimple(query.InvitationModel.InvitationStatus.Value(status)); err != nil {
+		return fmt.Errorf("update_status_error||err=%v||id=%s", err, invitation.ID)
+	}
+	invitation.InvitationStatus = status
+	if status != InvitationStatusPending {
+		writeBackStatus(ctx, Range{RecordID: invitation.RecordID}, status, "")
+	}
+	return nil
+}
+
+// transitionInvitationStatus 只在当前状态属于 from 时更新；未更新时重新读取记录，invitation 为当前状态
+func transitionInvitationStatus(ctx context.Context, invitation *model.InvitationModel, from []string, status string) (bool, error) {
+	t := query.InvitationModel
+	info, err := t.WithContext(ctx).
+		Where(t.ID.Eq(invitation.ID), t.InvitationStatus.In(from...)).
+		UpdateColumnSimple(t.InvitationStatus.Value(status), t.UpdatedAt.Value(time.Now()))
+	if err != nil {
+		return false, fmt.Errorf("update_status_error||err=%v||id=%s", err, invitation.ID)
+	}
+	if info.RowsAffected == 0 {
+		current, err := t.WithContext(ctx).Where(t.ID.Eq(invitation.ID)).First()
+		if err != nil {
+			return false, fmt.Errorf("find_invitation_error||err=%v", err)
+		}
+		*invitation = *current
+		return false, nil
+	}
+	invitation.InvitationStatus = status
+	if status != InvitationStatusPending {
+		writeBackStatus(ctx, Range{RecordID: invitation.RecordID}, status, "")
+	}
+	return true, nil
+}
+
+// invitationContent 用已有记录重新构造一行，用于重试
+func invitationContent(invitation *model.InvitationModel) Range {
+	return Range{
+		OrderID:        invitation.OrderID,
+		GithubUsername: invitation.GithubUsername,
+		GithubEmail:    invitation.GithubEmail,
+		RecordID:       invitation.RecordID,
+		OrderSource:    invitation.OrderSource,
+	}
+}
+
+func retryInvitation(invitation *model.InvitationModel) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	content := invitationContent(invitation)
+	err := checkAccess(ctx, content)
+	if err == nil {
+		err = InviteWrapper(ctx, content)
+	}
+	switch {
+	case err == nil:
+		writeBackStatus(ctx, content, InvitationStatusSucceeded, "")
+	case errors.Is(err, ErrBlocked):
+		writeBackStatus(ctx, content, InvitationStatusBlocked, err.Error())
+	case errors.Is(err, ErrAlreadyInvited), errors.Is(err, ErrIgnored), errors.Is(err, ErrEntitlementExpired):
+		writeBackStatus(ctx, content, InvitationStatusSkipped, err.Error())
+	default:
+		logrus.WithError(err).WithField("invitationID", invitation.ID).Error("retry_invite_error")
+		writeBackStatus(ctx, content, InvitationStatusFailed, err.Error())
+	}
+}
+
+func handledCard(invitation *model.InvitationModel, kind, result, openID string) map[string]any {
+	template := "green"
+	if kind == CardActionIgnore {
+		template = "grey"
+	}
+	return map[string]any{
+		"config": map[string]any{"wide_screen_mode": true},
+		"header": map[string]any{
+			"template": template,
+			"title":    map[string]any{"tag": "plain_text", "content": "GitHub 组织邀请失败（已处理）"},
+		},
+		"elements": []any{
+			invitationCardFields(invitation, invitation.LastError),
+			map[string]any{
+				"tag": "note",
+				"elements": []any{map[string]any{
+					"tag":     "lark_md",
+					"content": fmt.Sprintf("<at id=%s></at> %s（%s）", openID, result, time.Now().Format(time.DateTime)),
+				}},
+			},
+		},
+	}
+}
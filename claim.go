@@ -0,0 +1,245 @@
+package main
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+//go:embed web/claim.html
+var claimPage []byte
+
+// ClaimRequest 买家自助领取的请求体
+type ClaimRequest struct {
+	// OrderID 会员购为订单号；爱发电为订单详情中的订单号（out_trade_no），超出 int64，按字符串处理
+	OrderID any `json:"order_id"`
+	// OrderSource 默认为 bilibili
+	OrderSource    string `json:"order_source"`
+	GithubUsername string `json:"github_username"`
+	GithubEmail    string `json:"github_email"`
+}
+
+// ClaimResponse 面向买家的结果，不包含内部错误和其他用户的信息
+type ClaimResponse struct {
+	Result  string `json:"result"`
+	Class   string `json:"class,omitempty"`
+	Message string `json:"message"`
+	// Status 该用户名在这个订单上最近一条记录的状态
+	Status    string     `json:"status,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// claimMessages 各种结果对买家展示的说明
+var claimMessages = map[string]string{
+	OutcomeSucceeded:        "邀请已发送，请查收 GitHub 邀请邮件并接受邀请",
+	OutcomeRenewed:          "续费成功，会员到期时间已顺延",
+	ClassAlreadyMember:      "该 GitHub 账号已经在组织中",
+	ClassAlreadyInvited:     "已经邀请过该账号，请查收 GitHub 邀请邮件",
+	ClassIgnored:            "该账号无法自助领取，请联系我们",
+	ClassBlocked:            "该账号或订单无法领取，请联系我们",
+	ClassEntitlementExpired: "该订单的会员已到期，请使用续费订单领取",
+	ClassAlreadyRenewed:     "该订单已经用于续费",
+	ClassNotPurchased:       "没有找到已支付的订单，请核对订单号",
+	ClassSuspectedAbuse:     "该订单已绑定其他 GitHub 账号，如需更换请联系我们",
+	ClassError:              "邀请失败，我们会尽快处理",
+}
+
+// claimPortal 买家自助领取页面，按 IP 和订单限流，防止枚举订单号
+type claimPortal struct {
+	trustedProxy bool
+	byIP         *rateLimiter
+	byOrder      *rateLimiter
+}
+
+// newClaimPortal claim.enabled 为 false，或会员购不核验订单时返回 nil
+func newClaimPortal() *claimPortal {
+	if !viper.GetBool("claim.enabled") {
+		return nil
+	}
+	if _, ok := purchaseVerifier.(noneVerifier); ok {
+		logrus.Warn("claim portal disabled, bilibili.verifier is none")
+		return nil
+	}
+	window := viper.GetDuration("claim.window")
+	if window <= 0 {
+		window = time.Hour
+	}
+	ipLimit, orderLimit := viper.GetInt("claim.ip_limit"), viper.GetInt("claim.order_limit")
+	if ipLimit <= 0 {
+		ipLimit = 10
+	}
+	if orderLimit <= 0 {
+		orderLimit = 5
+	}
+	return &claimPortal{
+		trustedProxy: viper.GetBool("claim.trusted_proxy"),
+		byIP:         newRateLimiter(ipLimit, window),
+		byOrder:      newRateLimiter(orderLimit, window),
+	}
+}
+
+// clientIP 部署在反向代理后时取代理追加在 X-Forwarded-For 末尾的地址
+func (p *claimPortal) clientIP(r *http.Request) string {
+	if p.trustedProxy {
+		if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+			parts := strings.Split(xff, ",")
+			return strings.TrimSpace(parts[len(parts)-1])
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func (p *claimPortal) page(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(claimPage)
+}
+
+// claim 核验订单后把用户名绑定到订单并立即邀请，已处理过的返回当前状态
+func (p *claimPortal) claim(w http.ResponseWriter, r *http.Request) {
+	var (
+		err        error
+		statusCode = http.StatusOK
+		req        ClaimRequest
+		ip         = p.clientIP(r)
+	)
+	defer func() {
+		if err != nil {
+			http.Error(w, err.Error(), statusCode)
+		}
+	}()
+
+	if ok, wait := p.byIP.allow(ip); !ok {
+		logrus.WithField("ip", ip).Warn("claim_ip_rate_limited")
+		statusCode, err = http.StatusTooManyRequests, tooManyClaims(w, wait)
+		return
+	}
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4<<10))
+	decoder.UseNumber()
+	if err = decoder.Decode(&req); err != nil {
+		statusCode = http.StatusBadRequest
+		err = errors.New("请求格式不正确")
+		return
+	}
+	switch req.OrderSource {
+	case "", OrderSourceBilibili, OrderSourceAfdian:
+	default:
+		statusCode = http.StatusBadRequest
+		err = errors.New("不支持的订单来源")
+		return
+	}
+	orderID := req.OrderID
+	if req.OrderSource == OrderSourceAfdian {
+		if orderID, statusCode, err = afdianClaimOrderID(r.Context(), req.OrderID); err != nil {
+			return
+		}
+	}
+	content, bad, ok := validateRow(rawRow{
+		OrderID:        orderID,
+		GithubUsername: req.GithubUsername,
+		GithubEmail:    req.GithubEmail,
+		OrderSource:    req.OrderSource,
+	})
+	if !ok {
+		statusCode = http.StatusBadRequest
+		err = errors.New("请填写订单号、GitHub 用户名和邮箱")
+		if bad != nil {
+			err = fmt.Errorf("填写有误：%s", bad.Reason)
+		}
+		return
+	}
+	source := content.orderSource()
+	logger := logrus.WithFields(logrus.Fields{
+		"ip":          ip,
+		"orderID":     content.OrderID,
+		"orderSource": source,
+		"githubName":  content.GithubUsername,
+	})
+	if ok, wait := p.byOrder.allow(source + ":" + strconv.FormatInt(content.OrderID, 10)); !ok {
+		logger.Warn("claim_order_rate_limited")
+		statusCode, err = http.StatusTooManyRequests, tooManyClaims(w, wait)
+		return
+	}
+
+	// 先核验订单和名额，未购买或已被占用时不产生邀请记录和失败通知
+	bought, err := purchase(r.Context(), source, content.OrderID)
+	if errors.Is(err, ErrNotPurchased) {
+		logger.WithError(err).Info("claim_not_purchased")
+		statusCode, err = http.StatusNotFound, errors.New(claimMessages[ClassNotPurchased])
+		return
+	}
+	if err != nil {
+		logger.WithError(err).Error("claim_verify_error")
+		statusCode, err = http.StatusBadGateway, errors.New("暂时无法核验订单，请稍后再试")
+		return
+	}
+	if err = checkSeats(r.Context(), content.GithubUsername, bought); errors.Is(err, ErrSuspectedAbuse) {
+		logger.WithError(err).Warn("claim_order_taken")
+		statusCode, err = http.StatusConflict, errors.New(claimMessages[ClassSuspectedAbuse])
+		return
+	}
+	if err != nil {
+		logger.WithError(err).Error("claim_seat_check_error")
+		statusCode, err = http.StatusInternalServerError, errors.New(claimMessages[ClassError])
+		return
+	}
+
+	outcome := inviteOne(r.Context(), content)
+	logger.WithFields(logrus.Fields{"result": outcome.Result, "class": outcome.Class}).Info("claim_done")
+	resp := ClaimResponse{Result: outcome.Result, Class: outcome.Class, Message: claimMessages[outcome.Class]}
+	if outcome.Class == "" {
+		resp.Message = claimMessages[outcome.Result]
+	}
+	if invitation, err2 := latestInvitation(r.Context(), content); err2 != nil {
+		logger.WithError(err2).Error("claim_find_invitation_error")
+	} else if invitation != nil {
+		resp.Status = invitation.InvitationStatus
+		if hasExpiry(invitation.ExpiresAt) {
+			resp.ExpiresAt = &invitation.ExpiresAt
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+var afdianTradeNoPattern = regexp.MustCompile(`^[0-9]{1,64}$`)
+
+// afdianClaimOrderID 买家只知道 out_trade_no，换成邀请记录中使用的 afdian_orders.id
+func afdianClaimOrderID(ctx context.Context, v any) (int64, int, error) {
+	if afdianClient == nil {
+		return 0, http.StatusBadRequest, errors.New("不支持的订单来源")
+	}
+	outTradeNo := strings.TrimSpace(fmt.Sprint(v))
+	if !afdianTradeNoPattern.MatchString(outTradeNo) {
+		return 0, http.StatusBadRequest, errors.New("填写有误：爱发电订单号应为订单详情中的一串数字")
+	}
+	order, err := findAfdianOrder(ctx, outTradeNo)
+	if err != nil {
+		logrus.WithError(err).WithField("outTradeNo", outTradeNo).Error("claim_find_afdian_order_error")
+		return 0, http.StatusBadGateway, errors.New("暂时无法核验订单，请稍后再试")
+	}
+	if order == nil {
+		return 0, http.StatusNotFound, errors.New(claimMessages[ClassNotPurchased])
+	}
+	return order.ID, http.StatusOK, nil
+}
+
+func tooManyClaims(w http.ResponseWriter, wait time.Duration) error {
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	return errors.New("请求过于频繁，请稍后再试")
+}
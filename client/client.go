@@ -0,0 +1,298 @@
+// Package client 是 /api/v1 的 Go 客户端，接口定义见 api/openapi.json：
+//
+//	c := client.New("http://localhost:8182", os.Getenv("API_KEY"))
+//	job, err := c.StartJob(ctx, client.StartJobRequest{Start: "A2", End: "C"})
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/model"
+)
+
+// Error 服务端返回的错误对象
+type Error struct {
+	StatusCode int             `json:"-"`
+	Code       string          `json:"code"`
+	Message    string          `json:"message"`
+	Details    json.RawMessage `json:"details,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("api error||status=%d||code=%s||message=%s", e.StatusCode, e.Code, e.Message)
+}
+
+type Client struct {
+	baseURL string
+	apiKey  string
+	// HTTPClient 默认 30 秒超时；同步邀请的接口（导入、批量重试）耗时较长时可以替换
+	HTTPClient *http.Client
+}
+
+// New baseURL 为服务地址，不含 /api/v1
+func New(baseURL, apiKey string) *Client {
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/") + "/api/v1",
+		apiKey:     apiKey,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// StartJob 创建邀请任务；同一数据源已有任务在运行时返回 409 的 *Error，Details 为该任务
+func (c *Client) StartJob(ctx context.Context, req StartJobRequest) (*JobCreated, error) {
+	return call[JobCreated](ctx, c, http.MethodPost, "/jobs", req)
+}
+
+// GetJob 任务进度和第一页的行，其余的行用 GetJobPage 按 NextCursor 取
+func (c *Client) GetJob(ctx context.Context, id string) (*Job, error) {
+	return c.GetJobPage(ctx, id, PageParams{})
+}
+
+func (c *Client) GetJobPage(ctx context.Context, id string, p PageParams) (*Job, error) {
+	return call[Job](ctx, c, http.MethodGet, "/jobs/"+url.PathEscape(id)+"?"+p.query().Encode(), nil)
+}
+
+// JobEvents 返回任务的 Server-Sent Events 流，事件见 GET /jobs/{id}/events，调用方负责关闭
+func (c *Client) JobEvents(ctx context.Context, id string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/jobs/"+url.PathEscape(id)+"/events", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	// 事件流持续到任务结束，不受 HTTPClient.Timeout 限制，由 ctx 控制
+	stream := *c.HTTPClient
+	stream.Timeout = 0
+	resp, err := c.send(&stream, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// ImportFile 上传 CSV/XLSX 文件，format 为空时按文件扩展名判断；header 为 false 时第一行是数据
+func (c *Client) ImportFile(ctx context.Context, fileName string, file io.Reader, format string, header bool) (*RunResult, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, err := mw.CreateFormFile("file", fileName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = io.Copy(part, file); err != nil {
+		return nil, err
+	}
+	if format != "" {
+		_ = mw.WriteField("format", format)
+	}
+	_ = mw.WriteField("header", strconv.FormatBool(header))
+	if err = mw.Close(); err != nil {
+		return nil, err
+	}
+	var out RunResult
+	if err = c.do(ctx, http.MethodPost, "/imports", mw.FormDataContentType(), &body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *Client) FindInvitations(ctx context.Context, p FindInvitationsParams) (*List[*InvitationDetail], error) {
+	q := url.Values{}
+	if p.OrderID != 0 {
+		q.Set("order_id", strconv.FormatInt(p.OrderID, 10))
+	}
+	setIf(q, "order_source", p.OrderSource)
+	setIf(q, "username", p.Username)
+	setIf(q, "email", p.Email)
+	return call[List[*InvitationDetail]](ctx, c, http.MethodGet, "/invitations?"+q.Encode(), nil)
+}
+
+func (c *Client) GetInvitation(ctx context.Context, id string) (*InvitationDetail, error) {
+	return call[InvitationDetail](ctx, c, http.MethodGet, "/invitations/"+url.PathEscape(id), nil)
+}
+
+func (c *Client) CreateInvitation(ctx context.Context, req CreateInvitationRequest) (*InvitationResponse, error) {
+	return call[InvitationResponse](ctx, c, http.MethodPost, "/invitations", req)
+}
+
+// RevokeInvitation 只能撤销 SUCCEEDED、RESOLVED 的记录，其余或没有可移除的成员、邀请时返回 conflict
+func (c *Client) RevokeInvitation(ctx context.Context, id string) (*model.InvitationModel, error) {
+	return call[model.InvitationModel](ctx, c, http.MethodPost, "/invitations/"+url.PathEscape(id)+"/revoke", nil)
+}
+
+// RetryFailed 重试 FAILED 记录；相同筛选条件的重试任务在运行时返回 409 的 *Error
+func (c *Client) RetryFailed(ctx context.Context, req RetryRequest) (*RetryResponse, error) {
+	return call[RetryResponse](ctx, c, http.MethodPost, "/invitations/retry", req)
+}
+
+func (c *Client) ListSuccesses(ctx context.Context, p ListParams) (*List[*model.SuccessfulInvitationModel], error) {
+	return call[List[*model.SuccessfulInvitationModel]](ctx, c, http.MethodGet, "/successes?"+p.query().Encode(), nil)
+}
+
+func (c *Client) ListFailures(ctx context.Context, p ListParams) (*List[*model.FailedInvitationModel], error) {
+	return call[List[*model.FailedInvitationModel]](ctx, c, http.MethodGet, "/failures?"+p.query().Encode(), nil)
+}
+
+func (c *Client) AbuseReport(ctx context.Context) (*AbuseReport, error) {
+	return call[AbuseReport](ctx, c, http.MethodGet, "/reports/abuse", nil)
+}
+
+// Health 每项依赖检查的耗时和最近一次错误；依赖不可用时同样返回结果，Status 为 unavailable
+func (c *Client) Health(ctx context.Context) (*Health, error) {
+	return call[Health](ctx, c, http.MethodGet, "/health", nil)
+}
+
+// ExportInvitations 返回导出文件的内容，调用方负责关闭
+func (c *Client) ExportInvitations(ctx context.Context, p ExportParams) (io.ReadCloser, error) {
+	q := p.query()
+	q.Del("limit")
+	q.Del("cursor")
+	setIf(q, "status", strings.Join(p.Status, ","))
+	setIf(q, "order_source", p.OrderSource)
+	setIf(q, "format", p.Format)
+	setIf(q, "columns", strings.Join(p.Columns, ","))
+	if p.MaskEmail {
+		q.Set("mask_email", "true")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/exports/invitations?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.send(c.HTTPClient, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (c *Client) ListAccessRules(ctx context.Context, p AccessRuleParams) (*List[*model.AccessRuleModel], error) {
+	q := url.Values{}
+	setIf(q, "list", p.List)
+	setIf(q, "kind", p.Kind)
+	setIf(q, "value", p.Value)
+	if p.IncludeExpired {
+		q.Set("include_expired", "true")
+	}
+	return call[List[*model.AccessRuleModel]](ctx, c, http.MethodGet, "/access-rules?"+q.Encode(), nil)
+}
+
+// CreateAccessRule 需要 admin，已有相同规则时返回 conflict
+func (c *Client) CreateAccessRule(ctx context.Context, req CreateAccessRuleRequest) (*model.AccessRuleModel, error) {
+	return call[model.AccessRuleModel](ctx, c, http.MethodPost, "/access-rules", req)
+}
+
+func (c *Client) UpdateAccessRule(ctx context.Context, id string, req UpdateAccessRuleRequest) (*model.AccessRuleModel, error) {
+	return call[model.AccessRuleModel](ctx, c, http.MethodPatch, "/access-rules/"+url.PathEscape(id), req)
+}
+
+func (c *Client) DeleteAccessRule(ctx context.Context, id string) (*model.AccessRuleModel, error) {
+	return call[model.AccessRuleModel](ctx, c, http.MethodDelete, "/access-rules/"+url.PathEscape(id), nil)
+}
+
+func (p ListParams) query() url.Values {
+	q := url.Values{}
+	setIf(q, "from", p.From)
+	setIf(q, "to", p.To)
+	setIf(q, "username", p.Username)
+	setIf(q, "email", p.Email)
+	setIf(q, "order", p.OrderID)
+	setIf(q, "sort", p.Sort)
+	setIf(q, "cursor", p.Cursor)
+	if p.Limit > 0 {
+		q.Set("limit", strconv.Itoa(p.Limit))
+	}
+	return q
+}
+
+func (p PageParams) query() url.Values {
+	q := url.Values{}
+	setIf(q, "cursor", p.Cursor)
+	if p.Limit > 0 {
+		q.Set("limit", strconv.Itoa(p.Limit))
+	}
+	return q
+}
+
+func setIf(q url.Values, key, value string) {
+	if value != "" {
+		q.Set(key, value)
+	}
+}
+
+func call[T any](ctx context.Context, c *Client, method, path string, in any) (*T, error) {
+	var out T
+	if err := c.doJSON(ctx, method, path, in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *Client) doJSON(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	contentType := ""
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body, contentType = bytes.NewReader(data), "application/json"
+	}
+	return c.do(ctx, method, path, contentType, body, out)
+}
+
+// do 成功时把 data 解到 out，失败时返回 *Error
+func (c *Client) do(ctx context.Context, method, path, contentType string, body io.Reader, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := c.send(c.HTTPClient, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	envelope := struct {
+		Data any `json:"data"`
+	}{Data: out}
+	if err = json.Unmarshal(data, &envelope); err != nil {
+		return fmt.Errorf("decode response error||status=%d||err=%w", resp.StatusCode, err)
+	}
+	return nil
+}
+
+// send 带上 API key 发出请求，状态码为 4xx、5xx 时读出错误对象并关闭响应
+func (c *Client) send(hc *http.Client, req *http.Request) (*http.Response, error) {
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < http.StatusBadRequest {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	var envelope struct {
+		Error *Error `json:"error"`
+	}
+	if json.Unmarshal(data, &envelope) != nil || envelope.Error == nil {
+		return nil, &Error{StatusCode: resp.StatusCode, Code: "unknown", Message: strings.TrimSpace(string(data))}
+	}
+	envelope.Error.StatusCode = resp.StatusCode
+	return nil, envelope.Error
+}
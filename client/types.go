@@ -0,0 +1,221 @@
+package client
+
+import (
+	"time"
+
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/model"
+)
+
+// 以下类型与 api/openapi.json 中的 schema 一一对应
+
+type StartJobRequest struct {
+	// Source 为 sheet（默认）或 bitable
+	Source string `json:"source,omitempty"`
+	Start  string `json:"start,omitempty"`
+	End    string `json:"end,omitempty"`
+	DryRun bool   `json:"dry_run,omitempty"`
+}
+
+type JobCreated struct {
+	JobID  string `json:"job_id"`
+	Status string `json:"status"`
+}
+
+type JobProgress struct {
+	Done      int32 `json:"done"`
+	Succeeded int32 `json:"succeeded"`
+	Renewed   int32 `json:"renewed"`
+	Skipped   int32 `json:"skipped"`
+	Failed    int32 `json:"failed"`
+	Invalid   int32 `json:"invalid"`
+}
+
+type Job struct {
+	ID         string                 `json:"id"`
+	Source     string                 `json:"source"`
+	Detail     string                 `json:"detail"`
+	Status     string                 `json:"status"`
+	DryRun     bool                   `json:"dry_run"`
+	Error      string                 `json:"error"`
+	Progress   JobProgress            `json:"progress"`
+	CreatedAt  time.Time              `json:"created_at"`
+	FinishedAt *time.Time             `json:"finished_at"`
+	Rows       []*model.BatchRowModel `json:"rows"`
+	NextCursor string                 `json:"next_cursor"`
+}
+
+// PageParams 任务中的行的分页参数
+type PageParams struct {
+	Limit  int
+	Cursor string
+}
+
+type InvalidRow struct {
+	Row            int    `json:"row"`
+	RecordID       string `json:"record_id"`
+	OrderID        string `json:"order_id"`
+	GithubUsername string `json:"github_username"`
+	GithubEmail    string `json:"github_email"`
+	Reason         string `json:"reason"`
+}
+
+type RunResult struct {
+	JobID     string       `json:"job_id"`
+	Progress  JobProgress  `json:"progress"`
+	Succeeded []string     `json:"succeeded"`
+	Renewed   []string     `json:"renewed"`
+	Skipped   []string     `json:"skipped"`
+	Failed    []string     `json:"failed"`
+	Invalid   []InvalidRow `json:"invalid"`
+}
+
+type Outcome struct {
+	Result string `json:"result"`
+	Action string `json:"action"`
+	Class  string `json:"class"`
+	Reason string `json:"reason"`
+}
+
+type Membership struct {
+	IsMember *bool  `json:"is_member"`
+	Error    string `json:"error"`
+}
+
+type InvitationDetail struct {
+	Invitation *model.InvitationModel          `json:"invitation"`
+	Attempts   []*model.InvitationAttemptModel `json:"attempts"`
+	FirstError string                          `json:"first_error"`
+	LastError  string                          `json:"last_error"`
+	Membership Membership                      `json:"membership"`
+}
+
+type FindInvitationsParams struct {
+	OrderID     int64
+	OrderSource string
+	Username    string
+	Email       string
+}
+
+type CreateInvitationRequest struct {
+	OrderID        int64  `json:"order_id"`
+	OrderSource    string `json:"order_source,omitempty"`
+	GithubUsername string `json:"github_username"`
+	GithubEmail    string `json:"github_email,omitempty"`
+	ExpiresAt      string `json:"expires_at,omitempty"`
+}
+
+type InvitationResponse struct {
+	Outcome    Outcome                `json:"outcome"`
+	Invitation *model.InvitationModel `json:"invitation"`
+}
+
+type RetryRequest struct {
+	IDs     []string `json:"ids,omitempty"`
+	Classes []string `json:"classes,omitempty"`
+	From    string   `json:"from,omitempty"`
+	To      string   `json:"to,omitempty"`
+	All     bool     `json:"all,omitempty"`
+	Force   bool     `json:"force,omitempty"`
+	Limit   int      `json:"limit,omitempty"`
+}
+
+// RetryResult 放入后台任务的记录 Outcome.Result 为 queued，Row 为任务中对应行的 RowNumber
+type RetryResult struct {
+	ID             string  `json:"id"`
+	OrderID        int64   `json:"order_id"`
+	GithubUsername string  `json:"github_username"`
+	Class          string  `json:"class"`
+	Row            int     `json:"row"`
+	Outcome        Outcome `json:"outcome"`
+}
+
+// RetryResponse 按 IDs 重试不超过 5 条时直接重试，Results 为最终结果；
+// 否则在后台任务 JobID 中重试，用 GetJob 查看结果
+type RetryResponse struct {
+	Total   int64          `json:"total"`
+	JobID   string         `json:"job_id"`
+	Queued  int            `json:"queued"`
+	Results []*RetryResult `json:"results"`
+}
+
+// ListParams 流水查询参数，零值的字段不传
+type ListParams struct {
+	From     string
+	To       string
+	Username string
+	Email    string
+	// OrderID 订单号子串
+	OrderID string
+	// Sort 为 asc 或 desc
+	Sort   string
+	Limit  int
+	Cursor string
+}
+
+type List[T any] struct {
+	Total      int64  `json:"total"`
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor"`
+}
+
+type AbuseReportItem struct {
+	Key    string   `json:"key"`
+	Count  int64    `json:"count"`
+	Values []string `json:"values"`
+}
+
+type AbuseReport struct {
+	SuspectedAbuseCnt int64                        `json:"suspected_abuse_cnt"`
+	Patterns          map[string][]AbuseReportItem `json:"patterns"`
+}
+
+// CheckResult 单项依赖检查的结果，LastError 为最近一次失败的原因，检查恢复后仍保留
+type CheckResult struct {
+	OK          bool       `json:"ok"`
+	LatencyMS   int64      `json:"latency_ms"`
+	CheckedAt   time.Time  `json:"checked_at"`
+	Error       string     `json:"error"`
+	LastError   string     `json:"last_error"`
+	LastErrorAt *time.Time `json:"last_error_at"`
+}
+
+// Health Checks 的键为 postgres、feishu、github
+type Health struct {
+	Status string                  `json:"status"`
+	Checks map[string]*CheckResult `json:"checks"`
+}
+
+// ExportParams 导出参数，Format 为 csv（默认）、xlsx 或 jsonl，Columns 为空时导出默认列
+type ExportParams struct {
+	ListParams
+	Status      []string
+	OrderSource string
+	Format      string
+	Columns     []string
+	MaskEmail   bool
+}
+
+// AccessRuleParams 名单查询参数，默认不含已到期的规则
+type AccessRuleParams struct {
+	List           string
+	Kind           string
+	Value          string
+	IncludeExpired bool
+}
+
+// CreateAccessRuleRequest List 默认为 block；block 优先于 allow，allow 只豁免 email_domain 的封禁。
+// ExpiresAt 不填表示永久有效
+type CreateAccessRuleRequest struct {
+	List        string `json:"list,omitempty"`
+	Kind        string `json:"kind"`
+	Value       string `json:"value"`
+	OrderSource string `json:"order_source,omitempty"`
+	Reason      string `json:"reason,omitempty"`
+	ExpiresAt   string `json:"expires_at,omitempty"`
+}
+
+// UpdateAccessRuleRequest 为 nil 的字段不修改，ExpiresAt 为空字符串表示永久有效
+type UpdateAccessRuleRequest struct {
+	Reason    *string `json:"reason,omitempty"`
+	ExpiresAt *string `json:"expires_at,omitempty"`
+}
@@ -0,0 +1,68 @@
+// fakeserver 启动外部服务的本地替身，配合 config.yaml 中的 base_url 做本地联调：
+//
+//	go run ./cmd/fakeserver -addr :9090 -orders orders.json
+//
+// orders.json 为 fake.MallOrder 数组。设置 -afdian-user 后同时提供爱发电查询订单接口，
+// 启动时打印 webhook 公钥；POST /fake/afdian/push?out_trade_no= 把订单推送到 -afdian-webhook
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/Nicknamezz00/org-invitation-autobot/fake"
+)
+
+func main() {
+	addr := flag.String("addr", ":9090", "listen address")
+	ordersFile := flag.String("orders", "", "JSON file with mall orders")
+	cookie := flag.String("cookie", "", "expected Cookie header, empty to skip the check")
+	afdianUser := flag.String("afdian-user", "", "afdian user_id, empty to disable the afdian stand-in")
+	afdianToken := flag.String("afdian-token", "", "afdian API token")
+	afdianOrdersFile := flag.String("afdian-orders", "", "JSON file with afdian orders")
+	afdianWebhook := flag.String("afdian-webhook", "http://localhost:8182/webhooks/afdian", "webhook url for pushed afdian orders")
+	flag.Parse()
+
+	var orders []fake.MallOrder
+	readJSON(*ordersFile, &orders)
+	mall := fake.NewMall(orders...)
+	mall.Cookie = *cookie
+
+	mux := http.NewServeMux()
+	mux.Handle("/mall-seller/", mall)
+
+	if *afdianUser != "" {
+		var afdianOrders []fake.AfdianOrder
+		readJSON(*afdianOrdersFile, &afdianOrders)
+		afdian, err := fake.NewAfdian(*afdianUser, *afdianToken, afdianOrders...)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		mux.Handle("/api/open/", afdian)
+		mux.HandleFunc("/fake/afdian/push", func(w http.ResponseWriter, r *http.Request) {
+			if err := afdian.Push(r.Context(), *afdianWebhook, r.URL.Query().Get("out_trade_no")); err != nil {
+				http.Error(w, err.Error(), http.StatusBadGateway)
+			}
+		})
+		log.Printf("afdian stand-in enabled, %d orders, afdian.public_key:\n%s", len(afdianOrders), afdian.PublicKeyPEM())
+	}
+
+	log.Printf("fake server listening %s, %d mall orders", *addr, len(orders))
+	log.Fatalln(http.ListenAndServe(*addr, mux))
+}
+
+func readJSON(file string, v any) {
+	if file == "" {
+		return
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	if err = json.Unmarshal(data, v); err != nil {
+		log.Fatalln(err)
+	}
+}
@@ -0,0 +1,504 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/model"
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/query"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gen"
+)
+
+const (
+	// dashboardCookie 保存登录后发放的会话 token，不保存 API key；每个请求仍按会话对应的 key 重新校验
+	dashboardCookie   = "oib_admin"
+	dashboardMaxAge   = 12 * 60 * 60
+	dashboardRuns     = 30
+	dashboardPageSize = 50
+)
+
+//go:embed web/admin
+var dashboardFS embed.FS
+
+// dashboardTemplates 每个页面与 layout.html 单独解析，页面中定义 content
+var dashboardTemplates = parseDashboardTemplates(
+	"login.html", "error.html", "runs.html", "run.html", "invitations.html", "invitation.html", "invite.html",
+)
+
+func parseDashboardTemplates(pages ...string) map[string]*template.Template {
+	funcs := template.FuncMap{
+		"datetime": formatOptionalTime,
+		"lower":    strings.ToLower,
+	}
+	templates := make(map[string]*template.Template, len(pages))
+	for _, page := range pages {
+		templates[page] = template.Must(template.New("layout.html").Funcs(funcs).
+			ParseFS(dashboardFS, "web/admin/layout.html", "web/admin/"+page))
+	}
+	return templates
+}
+
+// dashboardPage 所有页面共用的数据，页面自身的数据在 Data 中
+type dashboardPage struct {
+	Title      string
+	Key        *APIKey
+	CSRF       string
+	CanOperate bool
+	Notice     string
+	Error      string
+	Data       any
+}
+
+// dashboardSession 已登录的调用方，csrf 随会话生成，表单提交时校验
+type dashboardSession struct {
+	key  *APIKey
+	csrf string
+}
+
+type dashboardHandler func(w http.ResponseWriter, r *http.Request, s *dashboardSession)
+
+// registerDashboard 管理后台，使用与接口相同的 API key 和角色：查看需要 viewer，重试、撤销、邀请需要 operator
+func registerDashboard(mux *http.ServeMux) {
+	mux.HandleFunc("GET /admin", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/admin/", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("GET /admin/login", dashboardLoginPage)
+	mux.HandleFunc("POST /admin/login", dashboardLogin)
+	mux.HandleFunc("POST /admin/logout", dashboardLogout)
+	mux.HandleFunc("GET /admin/{$}", requireDashboard(RoleViewer, dashboardRunsPage))
+	mux.HandleFunc("GET /admin/runs/{id}", requireDashboard(RoleViewer, dashboardRunPage))
+	mux.HandleFunc("GET /admin/runs/{id}/events", requireDashboard(RoleViewer, func(w http.ResponseWriter, r *http.Request, _ *dashboardSession) {
+		jobEventStream(w, r)
+	}))
+	mux.HandleFunc("GET /admin/invitations", requireDashboard(RoleViewer, dashboardInvitationsPage))
+	mux.HandleFunc("GET /admin/invitations/{id}", requireDashboard(RoleViewer, dashboardInvitationPage))
+	mux.HandleFunc("POST /admin/invitations/{id}/retry", requireDashboard(RoleOperator, dashboardRetry))
+	mux.HandleFunc("POST /admin/invitations/{id}/revoke", requireDashboard(RoleOperator, dashboardRevoke))
+	mux.HandleFunc("GET /admin/invite", requireDashboard(RoleOperator, dashboardInvitePage))
+	mux.HandleFunc("POST /admin/invite", requireDashboard(RoleOperator, dashboardInvite))
+}
+
+// dashboardSessions 会话只保存在内存中，重启后需要重新登录
+var dashboardSessions = &sessionStore{sessions: make(map[string]*storedSession)}
+
+type storedSession struct {
+	keyID     string
+	csrf      string
+	expiresAt time.Time
+}
+
+// sessionStore 以 token 的哈希为键，token 本身只出现在 cookie 中
+type sessionStore struct {
+	mu       sync.Mutex
+	sessions map[string]*storedSession
+}
+
+func (st *sessionStore) create(keyID string) string {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	now := time.Now()
+	for k, v := range st.sessions {
+		if now.After(v.expiresAt) {
+			delete(st.sessions, k)
+		}
+	}
+	token := randomToken()
+	st.sessions[hashAPIKey(token)] = &storedSession{keyID: keyID, csrf: randomToken(), expiresAt: now.Add(dashboardMaxAge * time.Second)}
+	return token
+}
+
+func (st *sessionStore) get(token string) *storedSession {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	session, ok := st.sessions[hashAPIKey(token)]
+	if !ok || time.Now().After(session.expiresAt) {
+		return nil
+	}
+	return session
+}
+
+func (st *sessionStore) delete(token string) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	delete(st.sessions, hashAPIKey(token))
+}
+
+// dashboardSessionKey 通过认证的 *dashboardSession 在 context 中的 key
+type dashboardSessionKey struct{}
+
+// authenticateDashboard 由 authMiddleware 调用，按登录 cookie 找到会话并重新校验对应的 key，未登录时跳转到登录页
+func authenticateDashboard(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	var (
+		key     *APIKey
+		err     = errors.New("missing session")
+		session *storedSession
+	)
+	if cookie, err2 := r.Cookie(dashboardCookie); err2 == nil {
+		if session = dashboardSessions.get(cookie.Value); session == nil {
+			err = errors.New("session expired")
+		}
+	}
+	if session != nil {
+		key, err = authenticateKeyID(r.Context(), session.keyID)
+	}
+	if err != nil {
+		if session != nil {
+			requestLogger(r).WithError(err).Warn("dashboard_unauthorized")
+		}
+		clearDashboardCookie(w, r)
+		http.Redirect(w, r, "/admin/login", http.StatusSeeOther)
+		return
+	}
+	s := &dashboardSession{key: key, csrf: session.csrf}
+	next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), dashboardSessionKey{}, s)))
+}
+
+// requireDashboard 要求 authenticateDashboard 认证的会话至少具有 role 权限；POST 请求还要校验 csrf
+func requireDashboard(role string, next dashboardHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := requestLogger(r)
+		s, ok := r.Context().Value(dashboardSessionKey{}).(*dashboardSession)
+		if !ok {
+			logger.Error("dashboard_session_missing_in_context")
+			http.Redirect(w, r, "/admin/login", http.StatusSeeOther)
+			return
+		}
+		key := s.key
+		logger = logger.WithFields(logrus.Fields{"keyID": key.ID, "keyName": key.Name, "role": key.Role})
+		if !key.allows(role) {
+			logger.WithField("required", role).Warn("dashboard_forbidden")
+			renderDashboardError(w, r, s, http.StatusForbidden, fmt.Errorf("role %s required", role))
+			return
+		}
+		if r.Method == http.MethodPost && subtle.ConstantTimeCompare([]byte(r.PostFormValue("csrf")), []byte(s.csrf)) != 1 {
+			logger.Warn("dashboard_csrf_mismatch")
+			renderDashboardError(w, r, s, http.StatusForbidden, errors.New("invalid csrf token, reload the page and try again"))
+			return
+		}
+		logger.Info("dashboard_request")
+		next(w, r, s)
+	}
+}
+
+func setDashboardCookie(w http.ResponseWriter, r *http.Request, value string, maxAge int) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     dashboardCookie,
+		Value:    value,
+		Path:     "/admin",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https",
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
+func clearDashboardCookie(w http.ResponseWriter, r *http.Request) {
+	setDashboardCookie(w, r, "", -1)
+}
+
+func renderDashboard(w http.ResponseWriter, r *http.Request, statusCode int, page string, s *dashboardSession, p dashboardPage) {
+	if s != nil {
+		p.Key, p.CSRF, p.CanOperate = s.key, s.csrf, s.key.allows(RoleOperator)
+	}
+	if p.Notice == "" {
+		p.Notice = r.URL.Query().Get("notice")
+	}
+	if p.Error == "" {
+		p.Error = r.URL.Query().Get("error")
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(statusCode)
+	if err := dashboardTemplates[page].Execute(w, p); err != nil {
+		logrus.WithError(err).WithField("page", page).Error("dashboard_render_error")
+	}
+}
+
+func renderDashboardError(w http.ResponseWriter, r *http.Request, s *dashboardSession, statusCode int, err error) {
+	renderDashboard(w, r, statusCode, "error.html", s, dashboardPage{Title: http.StatusText(statusCode), Error: err.Error()})
+}
+
+// redirectDashboard 操作完成后跳转，结果通过 notice 或 error 参数显示在页面顶部
+func redirectDashboard(w http.ResponseWriter, r *http.Request, path string, notice string, err error) {
+	q := url.Values{}
+	if err != nil {
+		q.Set("error", err.Error())
+	} else if notice != "" {
+		q.Set("notice", notice)
+	}
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	http.Redirect(w, r, path, http.StatusSeeOther)
+}
+
+func dashboardLoginPage(w http.ResponseWriter, r *http.Request) {
+	renderDashboard(w, r, http.StatusOK, "login.html", nil, dashboardPage{Title: "登录"})
+}
+
+func dashboardLogin(w http.ResponseWriter, r *http.Request) {
+	raw := strings.TrimSpace(r.PostFormValue("api_key"))
+	key, err := authenticate(r.Context(), raw)
+	if err != nil {
+		logrus.WithError(err).WithField("remote", r.RemoteAddr).Warn("dashboard_login_failed")
+		renderDashboard(w, r, http.StatusUnauthorized, "login.html", nil, dashboardPage{Title: "登录", Error: err.Error()})
+		return
+	}
+	logrus.WithFields(logrus.Fields{"keyID": key.ID, "keyName": key.Name, "role": key.Role}).Info("dashboard_login")
+	setDashboardCookie(w, r, dashboardSessions.create(key.ID), dashboardMaxAge)
+	http.Redirect(w, r, "/admin/", http.StatusSeeOther)
+}
+
+func dashboardLogout(w http.ResponseWriter, r *http.Request) {
+	if cookie, err := r.Cookie(dashboardCookie); err == nil {
+		dashboardSessions.delete(cookie.Value)
+	}
+	clearDashboardCookie(w, r)
+	http.Redirect(w, r, "/admin/login", http.StatusSeeOther)
+}
+
+// dashboardRunsPage 最近的运行（批次）
+func dashboardRunsPage(w http.ResponseWriter, r *http.Request, s *dashboardSession) {
+	t := query.BatchModel
+	batches, err := t.WithContext(r.Context()).Order(t.CreatedAt.Desc()).Limit(dashboardRuns).Find()
+	if err != nil {
+		renderDashboardError(w, r, s, http.StatusInternalServerError, fmt.Errorf("find_batch_error||err=%v", err))
+		return
+	}
+	runs := make([]*JobResponse, 0, len(batches))
+	for _, batch := range batches {
+		runs = append(runs, newJobResponse(batch, nil))
+	}
+	renderDashboard(w, r, http.StatusOK, "runs.html", s, dashboardPage{Title: "最近运行", Data: runs})
+}
+
+// dashboardRunPage 一次运行中每一行的结果
+// dashboardRun 运行详情页的数据，行按处理顺序分页；Cursor 为当前页的 cursor 参数，事件流从这里开始回放
+type dashboardRun struct {
+	*JobResponse
+	Cursor  string
+	NextURL string
+}
+
+func dashboardRunPage(w http.ResponseWriter, r *http.Request, s *dashboardSession) {
+	var (
+		cursor = r.URL.Query().Get("cursor")
+		p      = ListParams{Limit: dashboardPageSize}
+		err    error
+	)
+	if cursor != "" {
+		if p.Cursor, err = decodeListCursor(cursor); err != nil {
+			renderDashboardError(w, r, s, http.StatusBadRequest, err)
+			return
+		}
+	}
+	batch, statusCode, err := findBatch(r.Context(), r.PathValue("id"))
+	if err != nil {
+		renderDashboardError(w, r, s, statusCode, err)
+		return
+	}
+	page, err := batchRowsPage(r.Context(), batch.ID, p)
+	if err != nil {
+		renderDashboardError(w, r, s, http.StatusInternalServerError, err)
+		return
+	}
+	data := &dashboardRun{JobResponse: newJobResponse(batch, page.Items), Cursor: cursor}
+	if page.NextCursor != "" {
+		data.NextURL = "/admin/runs/" + batch.ID + "?" + url.Values{"cursor": {page.NextCursor}}.Encode()
+	}
+	renderDashboard(w, r, http.StatusOK, "run.html", s, dashboardPage{Title: "运行详情", Data: data})
+}
+
+// dashboardInvitations 邀请列表页的数据，q 同时匹配用户名、邮箱和订单号
+type dashboardInvitations struct {
+	Query    string
+	Status   string
+	Statuses []string
+	List     *ListResponse[*model.InvitationModel]
+	NextURL  string
+}
+
+// dashboardStatuses 列表页可筛选的状态
+var dashboardStatuses = []string{
+	InvitationStatusPending, InvitationStatusSucceeded, InvitationStatusFailed, InvitationStatusIgnored,
+	InvitationStatusResolved, InvitationStatusSuspectedAbuse, InvitationStatusExpired, InvitationStatusRevoked,
+}
+
+// dashboardInvitationsPage 按最近更新时间倒序分页
+func dashboardInvitationsPage(w http.ResponseWriter, r *http.Request, s *dashboardSession) {
+	var (
+		q    = r.URL.Query()
+		t    = query.InvitationModel
+		data = &dashboardInvitations{
+			Query:    strings.TrimSpace(q.Get("q")),
+			Status:   q.Get("status"),
+			Statuses: dashboardStatuses,
+		}
+		p   = ListParams{Limit: dashboardPageSize}
+		err error
+	)
+	if v := q.Get("cursor"); v != "" {
+		if p.Cursor, err = decodeListCursor(v); err != nil {
+			renderDashboardError(w, r, s, http.StatusBadRequest, err)
+			return
+		}
+	}
+	if data.Status != "" && !slices.Contains(dashboardStatuses, data.Status) {
+		renderDashboardError(w, r, s, http.StatusBadRequest, fmt.Errorf("invalid status %q", data.Status))
+		return
+	}
+
+	var filters []gen.Condition
+	if data.Query != "" {
+		pattern := containsPattern(strings.ToLower(data.Query))
+		filters = append(filters, t.WithContext(r.Context()).
+			Where(t.GithubUsername.Lower().Like(pattern)).
+			Or(t.GithubEmail.Lower().Like(pattern)).
+			Or(orderIDContains(data.Query)))
+	}
+	if data.Status != "" {
+		filters = append(filters, t.InvitationStatus.Eq(data.Status))
+	}
+	cols := listColumns{id: t.ID, at: t.UpdatedAt, username: t.GithubUsername, email: t.GithubEmail}
+	total, err := t.WithContext(r.Context()).Where(filters...).Count()
+	if err != nil {
+		renderDashboardError(w, r, s, http.StatusInternalServerError, fmt.Errorf("count_invitation_error||err=%v", err))
+		return
+	}
+	conds, order := cols.page(p)
+	invitations, err := t.WithContext(r.Context()).
+		Where(append(conds, filters...)...).
+		Order(order...).
+		Limit(p.Limit + 1).
+		Find()
+	if err != nil {
+		renderDashboardError(w, r, s, http.StatusInternalServerError, fmt.Errorf("find_invitation_error||err=%v", err))
+		return
+	}
+	data.List = paginate(p, total, invitations, func(m *model.InvitationModel) listCursor {
+		return listCursor{At: m.UpdatedAt, ID: m.ID}
+	})
+	if data.List.NextCursor != "" {
+		next := url.Values{"cursor": {data.List.NextCursor}}
+		if data.Query != "" {
+			next.Set("q", data.Query)
+		}
+		if data.Status != "" {
+			next.Set("status", data.Status)
+		}
+		data.NextURL = "/admin/invitations?" + next.Encode()
+	}
+	renderDashboard(w, r, http.StatusOK, "invitations.html", s, dashboardPage{Title: "邀请记录", Data: data})
+}
+
+// dashboardInvitation 详情页的数据，以及当前状态下可用的操作
+type dashboardInvitation struct {
+	*InvitationDetail
+	// Member 是、否，查询失败时为空
+	Member    string
+	Retryable bool
+	Permanent bool
+	Revocable bool
+}
+
+func dashboardInvitationPage(w http.ResponseWriter, r *http.Request, s *dashboardSession) {
+	invitation, statusCode, err := findInvitation(r.Context(), r.PathValue("id"))
+	if err != nil {
+		renderDashboardError(w, r, s, statusCode, err)
+		return
+	}
+	details, err := invitationDetails(r.Context(), []*model.InvitationModel{invitation})
+	if err != nil {
+		renderDashboardError(w, r, s, http.StatusInternalServerError, err)
+		return
+	}
+	data := &dashboardInvitation{
+		InvitationDetail: details[0],
+		Retryable:        retryable(invitation),
+		Permanent:        slices.Contains(permanentClasses, failedClass(invitation)),
+		Revocable:        slices.Contains(revocableStatuses, invitation.InvitationStatus),
+	}
+	if isMember := data.Membership.IsMember; isMember != nil {
+		data.Member = map[bool]string{true: "是", false: "否"}[*isMember]
+	}
+	renderDashboard(w, r, http.StatusOK, "invitation.html", s, dashboardPage{Title: "邀请详情", Data: data})
+}
+
+func dashboardRetry(w http.ResponseWriter, r *http.Request, s *dashboardSession) {
+	invitation, statusCode, err := findInvitation(r.Context(), r.PathValue("id"))
+	if err != nil {
+		renderDashboardError(w, r, s, statusCode, err)
+		return
+	}
+	path := "/admin/invitations/" + invitation.ID
+	if !retryable(invitation) {
+		redirectDashboard(w, r, path, "", fmt.Errorf("only FAILED or stale PENDING invitations can be retried, status is %s", invitation.InvitationStatus))
+		return
+	}
+	result := retryOne(r.Context(), invitation, r.PostFormValue("force") != "")
+	redirectDashboard(w, r, path, "重试结果："+outcomeText(result.Outcome), nil)
+}
+
+func dashboardRevoke(w http.ResponseWriter, r *http.Request, s *dashboardSession) {
+	invitation, statusCode, err := findInvitation(r.Context(), r.PathValue("id"))
+	if err != nil {
+		renderDashboardError(w, r, s, statusCode, err)
+		return
+	}
+	path := "/admin/invitations/" + invitation.ID
+	if err = revokeInvitation(r.Context(), invitation); err != nil {
+		redirectDashboard(w, r, path, "", err)
+		return
+	}
+	redirectDashboard(w, r, path, "已撤销，已移出组织或撤回邀请", nil)
+}
+
+func dashboardInvitePage(w http.ResponseWriter, r *http.Request, s *dashboardSession) {
+	renderDashboard(w, r, http.StatusOK, "invite.html", s, dashboardPage{Title: "手动邀请", Data: CreateInvitationRequest{}})
+}
+
+// dashboardInvite 与 POST /invitations 相同的流程，完成后跳转到对应的邀请记录
+func dashboardInvite(w http.ResponseWriter, r *http.Request, s *dashboardSession) {
+	req := CreateInvitationRequest{
+		OrderID:        strings.TrimSpace(r.PostFormValue("order_id")),
+		OrderSource:    r.PostFormValue("order_source"),
+		GithubUsername: strings.TrimSpace(r.PostFormValue("github_username")),
+		GithubEmail:    strings.TrimSpace(r.PostFormValue("github_email")),
+		ExpiresAt:      strings.TrimSpace(r.PostFormValue("expires_at")),
+	}
+	content, err := req.content()
+	if err != nil {
+		renderDashboard(w, r, http.StatusBadRequest, "invite.html", s, dashboardPage{Title: "手动邀请", Error: err.Error(), Data: req})
+		return
+	}
+	outcome := inviteOne(r.Context(), content)
+	notice := "邀请结果：" + outcomeText(outcome)
+	invitation, err := latestInvitation(r.Context(), content)
+	if err != nil || invitation == nil {
+		redirectDashboard(w, r, "/admin/invitations?q="+url.QueryEscape(content.GithubUsername), notice, err)
+		return
+	}
+	redirectDashboard(w, r, "/admin/invitations/"+invitation.ID, notice, nil)
+}
+
+// outcomeText 如 failed（user_not_found）：github user not found
+func outcomeText(o Outcome) string {
+	text := o.Result
+	if o.Class != "" {
+		text += "（" + o.Class + "）"
+	}
+	if o.Reason != "" {
+		text += "：" + o.Reason
+	}
+	return text
+}
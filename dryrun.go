@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+)
+
+// 试运行中每一行计划的动作
+const (
+	PlanInvite             = "invite"
+	PlanRenew              = "renew"
+	PlanSkipMember         = "skip-member"
+	PlanSkipAlreadyInvited = "skip-already-invited"
+	PlanSkipIgnored        = "skip-ignored"
+	PlanSkipBlocked        = "skip-blocked"
+	PlanSkipAlreadyRenewed = "skip-already-renewed"
+	PlanSkipExpired        = "skip-expired"
+	PlanReject             = "reject"
+)
+
+// dryRunState 本次试运行已计划的邀请，用于模拟同一批次内后续行的去重和名额占用
+type dryRunState struct {
+	invited map[string]bool
+	holders map[string][]string
+}
+
+func newDryRunState() *dryRunState {
+	return &dryRunState{invited: make(map[string]bool), holders: make(map[string][]string)}
+}
+
+func orderKey(source string, orderID int64) string {
+	return source + ":" + strconv.FormatInt(orderID, 10)
+}
+
+// planOne 与 inviteOne 的判断顺序相同，但只读：
+// 不调用 GitHub 的写接口，不写 invitations、renewals，也不回写表格
+func (s *dryRunState) planOne(ctx context.Context, content Range) Outcome {
+	if err := checkAccess(ctx, content); err != nil {
+		return planOutcome(err)
+	}
+	key := orderKey(content.orderSource(), content.OrderID)
+	renewal, err := planRenewal(ctx, content, s.holders[key]...)
+	switch {
+	case err != nil:
+		return planOutcome(err)
+	case renewal != nil:
+		s.hold(key, content.GithubUsername)
+		return Outcome{Result: OutcomeRenewed, Action: PlanRenew}
+	}
+
+	if isMember, err := CheckIfUserIsMember(ctx, content.GithubUsername); err != nil {
+		logrus.WithError(err).WithField("githubName", content.GithubUsername).Error("check_error")
+	} else if isMember {
+		return Outcome{Result: OutcomeSkipped, Action: PlanSkipMember, Class: ClassAlreadyMember, Reason: "already a member"}
+	}
+	if s.invited[content.GithubUsername] {
+		return planOutcome(ErrAlreadyInvited)
+	}
+	if err = checkInvited(ctx, content); err != nil {
+		return planOutcome(err)
+	}
+	bought, err := purchase(ctx, content.orderSource(), content.OrderID)
+	if err != nil {
+		return planOutcome(err)
+	}
+	if err = checkSeats(ctx, content.GithubUsername, bought, s.holders[key]...); err != nil {
+		return planOutcome(err)
+	}
+	s.invited[content.GithubUsername] = true
+	s.hold(key, content.GithubUsername)
+	return Outcome{Result: OutcomeSucceeded, Action: PlanInvite}
+}
+
+func (s *dryRunState) hold(key, username string) {
+	s.holders[key] = append(s.holders[key], username)
+}
+
+// planOutcome 跳过的原因对应各自的 skip 动作，其余都是 reject
+func planOutcome(err error) Outcome {
+	outcome := outcomeOf(err)
+	switch {
+	case errors.Is(err, ErrAlreadyInvited):
+		outcome.Action = PlanSkipAlreadyInvited
+	case errors.Is(err, ErrIgnored):
+		outcome.Action = PlanSkipIgnored
+	case errors.Is(err, ErrBlocked):
+		outcome.Action = PlanSkipBlocked
+	case errors.Is(err, ErrAlreadyRenewed):
+		outcome.Action = PlanSkipAlreadyRenewed
+	case errors.Is(err, ErrEntitlementExpired):
+		outcome.Action = PlanSkipExpired
+	default:
+		outcome.Result, outcome.Action = OutcomeFailed, PlanReject
+	}
+	return outcome
+}
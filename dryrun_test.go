@@ -0,0 +1,42 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestPlanOutcome(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		result string
+		action string
+		class  string
+	}{
+		{name: "already invited", err: ErrAlreadyInvited, result: OutcomeSkipped, action: PlanSkipAlreadyInvited, class: ClassAlreadyInvited},
+		{name: "ignored", err: ErrIgnored, result: OutcomeSkipped, action: PlanSkipIgnored, class: ClassIgnored},
+		{name: "blocked", err: fmt.Errorf("%w by username octocat: spam", ErrBlocked), result: OutcomeSkipped, action: PlanSkipBlocked, class: ClassBlocked},
+		{name: "already renewed", err: ErrAlreadyRenewed, result: OutcomeSkipped, action: PlanSkipAlreadyRenewed, class: ClassAlreadyRenewed},
+		{name: "expired", err: ErrEntitlementExpired, result: OutcomeSkipped, action: PlanSkipExpired, class: ClassEntitlementExpired},
+		{name: "not purchased", err: fmt.Errorf("order 1: %w", ErrNotPurchased), result: OutcomeFailed, action: PlanReject, class: ClassNotPurchased},
+		{name: "suspected abuse", err: ErrSuspectedAbuse, result: OutcomeFailed, action: PlanReject, class: ClassSuspectedAbuse},
+		{name: "user not found", err: ErrGithubUserNotFound, result: OutcomeFailed, action: PlanReject, class: ClassUserNotFound},
+		{name: "other error", err: errors.New("boom"), result: OutcomeFailed, action: PlanReject, class: ClassError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := planOutcome(tt.err)
+			if got.Result != tt.result || got.Action != tt.action || got.Class != tt.class {
+				t.Errorf("planOutcome(%v) = %+v, want result %q action %q class %q", tt.err, got, tt.result, tt.action, tt.class)
+			}
+			if got.Reason != tt.err.Error() {
+				t.Errorf("Reason = %q, want %q", got.Reason, tt.err.Error())
+			}
+			// 跳过与否要和正式运行一致
+			if real := outcomeOf(tt.err); real.Result != got.Result {
+				t.Errorf("Result = %q, but a real run gives %q", got.Result, real.Result)
+			}
+		})
+	}
+}
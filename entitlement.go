@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/model"
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/query"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+const defaultExpiryWarnDays = 7
+
+var (
+	ErrAlreadyRenewed     = errors.New("already renewed, skip")
+	ErrEntitlementExpired = errors.New("entitlement expired, skip")
+)
+
+// hasExpiry expires_at 为默认的 epoch 时表示永久有效
+func hasExpiry(t time.Time) bool {
+	return !isUnset(t)
+}
+
+// productDuration 商品的有效天数，来自商品配置中的 duration_days，0 表示永久有效
+func productDuration(p *Purchase) int {
+	return cast.ToInt(p.productConfig()["duration_days"])
+}
+
+// entitlementExpiry 表格中填写的到期时间优先，否则按商品时长从 from 起算；都没有时返回零值
+func entitlementExpiry(content Range, p *Purchase, from time.Time) time.Time {
+	if !content.ExpiresAt.IsZero() {
+		return content.ExpiresAt
+	}
+	if days := productDuration(p); days > 0 {
+		return from.AddDate(0, 0, days)
+	}
+	return time.Time{}
+}
+
+// renewalPlan 核验通过、尚未写入的续费
+type renewalPlan struct {
+	current   *model.InvitationModel
+	expiresAt time.Time
+}
+
+// planRenewal 只读地判断是否为续费行并完成核验，不写任何数据；
+// 返回 nil, nil 表示不是续费行，按普通邀请处理。extra 见 checkSeats
+func planRenewal(ctx context.Context, content Range, extra ...string) (*renewalPlan, error) {
+	t := query.InvitationModel
+	current, err := t.WithContext(ctx).
+		Where(
+			t.InvitationStatus.Eq(InvitationStatusSucceeded),
+			t.GithubUsername.Eq(content.GithubUsername),
+			t.ExpiresAt.Gt(epochFloor),
+		).
+		Order(t.ExpiresAt.Desc()).
+		First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find_entitlement_error||err=%v", err)
+	}
+	source := content.orderSource()
+	if current.OrderSource == source && current.OrderID == content.OrderID {
+		return nil, nil
+	}
+
+	r := query.RenewalModel
+	existing, err := r.WithContext(ctx).Where(r.OrderSource.Eq(source), r.OrderID.Eq(content.OrderID)).First()
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("find_renewal_error||err=%v", err)
+	}
+	if existing != nil {
+		if existing.GithubUsername != content.GithubUsername {
+			return nil, fmt.Errorf("%w: order %d already renewed %s", ErrSuspectedAbuse, content.OrderID, existing.GithubUsername)
+		}
+		return nil, ErrAlreadyRenewed
+	}
+
+	bought, err := purchase(ctx, source, content.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("not purchased||orderID=%d||name=%s||err=%w", content.OrderID, content.GithubUsername, err)
+	}
+	if err = checkSeats(ctx, content.GithubUsername, bought, extra...); err != nil {
+		return nil, fmt.Errorf("seat check failed||orderID=%d||name=%s||err=%w", content.OrderID, content.GithubUsername, err)
+	}
+	// 提前续费从原到期时间顺延，过期后续费从现在起算
+	from := time.Now()
+	if current.ExpiresAt.After(from) {
+		from = current.ExpiresAt
+	}
+	expiresAt := entitlementExpiry(content, bought, from)
+	if !hasExpiry(expiresAt) {
+		// 新订单的商品没有时长，不算续费
+		return nil, nil
+	}
+	if !expiresAt.After(current.ExpiresAt) {
+		return nil, fmt.Errorf("renewal does not extend expiry||orderID=%d||current=%s||expiresAt=%s",
+			content.OrderID, current.ExpiresAt.Format(time.DateTime), expiresAt.Format(time.DateTime))
+	}
+	return &renewalPlan{current: current, expiresAt: expiresAt}, nil
+}
+
+// renewEntitlement 已邀请成功、有到期时间的用户出现新订单时，延长到期时间而不是重复邀请；
+// renewed 为 false 表示不是续费行，按普通邀请处理
+func renewEntitlement(ctx context.Context, content Range) (renewed bool, err error) {
+	plan, err := planRenewal(ctx, content)
+	if err != nil {
+		return true, err
+	}
+	if plan == nil {
+		return false, nil
+	}
+	var (
+		current   = plan.current
+		source    = content.orderSource()
+		expiresAt = plan.expiresAt
+	)
+	err = query.Q.Transaction(func(tx *query.Query) error {
+		if err := tx.RenewalModel.WithContext(ctx).Create(&model.RenewalModel{
+			ID:                uuid.New().String(),
+			InvitationID:      current.ID,
+			OrderSource:       source,
+			OrderID:           content.OrderID,
+			GithubUsername:    content.GithubUsername,
+			PreviousExpiresAt: current.ExpiresAt,
+			ExpiresAt:         expiresAt,
+		}); err != nil {
+			return err
+		}
+		_, err := tx.InvitationModel.WithContext(ctx).
+			Where(tx.InvitationModel.ID.Eq(current.ID)).
+			UpdateColumnSimple(
+				tx.InvitationModel.ExpiresAt.Value(expiresAt),
+				tx.InvitationModel.ExpiryWarnedAt.Value(time.Unix(0, 0)),
+				tx.InvitationModel.UpdatedAt.Value(time.Now()),
+			)
+		return err
+	})
+	if err != nil {
+		return true, fmt.Errorf("renew_error||err=%v||id=%s", err, current.ID)
+	}
+	logrus.WithFields(logrus.Fields{
+		"invitationID": current.ID,
+		"orderID":      content.OrderID,
+		"githubName":   content.GithubUsername,
+		"expiresAt":    expiresAt,
+	}).Info("renew_success")
+	return true, nil
+}
+
+// runExpiryJob 每天运行一次：提前 entitlements.warn_days 天提醒即将到期的会员，把已到期的移出组织
+func runExpiryJob(ctx context.Context) {
+	warnDays := viper.GetInt("entitlements.warn_days")
+	if warnDays <= 0 {
+		warnDays = defaultExpiryWarnDays
+	}
+	now := time.Now()
+	t := query.InvitationModel
+
+	expiring, err := t.WithContext(ctx).
+		Where(
+			t.InvitationStatus.Eq(InvitationStatusSucceeded),
+			t.ExpiresAt.Gt(now),
+			t.ExpiresAt.Lte(now.AddDate(0, 0, warnDays)),
+			t.ExpiryWarnedAt.Lt(epochFloor),
+		).
+		Find()
+	if err != nil {
+		logrus.WithError(err).Error("_db_find_expiring_error")
+	}
+	for _, invitation := range expiring {
+		if err = notifier.EntitlementExpiring(ctx, invitation); err != nil {
+			logrus.WithError(err).WithField("invitationID", invitation.ID).Error("notify_error")
+			continue
+		}
+		if _, err = t.WithContext(ctx).
+			Where(t.ID.Eq(invitation.ID)).
+			UpdateColumnSimple(t.ExpiryWarnedAt.Value(now)); err != nil {
+			logrus.WithError(err).WithField("invitationID", invitation.ID).Error("_db_update_warned_error")
+		}
+	}
+
+	expired, err := t.WithContext(ctx).
+		Where(
+			t.InvitationStatus.Eq(InvitationStatusSucceeded),
+			t.ExpiresAt.Gt(epochFloor),
+			t.ExpiresAt.Lte(now),
+		).
+		Find()
+	if err != nil {
+		logrus.WithError(err).Error("_db_find_expired_error")
+	}
+	for _, invitation := range expired {
+		logger := logrus.WithFields(logrus.Fields{
+			"invitationID": invitation.ID,
+			"githubName":   invitation.GithubUsername,
+			"expiresAt":    invitation.ExpiresAt,
+		})
+		// 移出失败时保持 SUCCEEDED，第二天重试
+		var cause string
+		// 已自行退出或邀请已过期时没有可移除的，照常标记为到期
+		if err = RemoveMember(ctx, invitation.GithubUsername, invitation.GithubEmail); err != nil && !errors.Is(err, ErrNothingToRemove) {
+			logger.WithError(err).Error("remove_member_error")
+			cause = err.Error()
+		} else if _, err = t.WithContext(ctx).
+			Where(t.ID.Eq(invitation.ID)).
+			UpdateColumnSimple(
+				t.InvitationStatus.Value(InvitationStatusExpired),
+				t.UpdatedAt.Value(now),
+			); err != nil {
+			logger.WithError(err).Error("_db_update_expired_error")
+		} else {
+			logger.Info("entitlement_expired")
+			writeBackStatus(ctx, Range{RecordID: invitation.RecordID}, InvitationStatusExpired, "")
+		}
+		if err = notifier.EntitlementExpired(ctx, invitation, cause); err != nil {
+			logger.WithError(err).Error("notify_error")
+		}
+	}
+}
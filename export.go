@@ -0,0 +1,275 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/model"
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/query"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	ExportCSV   = "csv"
+	ExportXLSX  = "xlsx"
+	ExportJSONL = "jsonl"
+)
+
+// exportColumn 导出的一列，value 返回 int64、string 或 time.Time（未设置的时间为零值）
+type exportColumn struct {
+	name  string
+	value func(m *model.InvitationModel) any
+}
+
+var exportColumns = []exportColumn{
+	{"id", func(m *model.InvitationModel) any { return m.ID }},
+	{"order_source", func(m *model.InvitationModel) any { return m.OrderSource }},
+	{"order_id", func(m *model.InvitationModel) any { return m.OrderID }},
+	{"github_username", func(m *model.InvitationModel) any { return m.GithubUsername }},
+	{"github_email", func(m *model.InvitationModel) any { return m.GithubEmail }},
+	{"invitation_status", func(m *model.InvitationModel) any { return m.InvitationStatus }},
+	{"created_at", func(m *model.InvitationModel) any { return m.CreatedAt }},
+	{"updated_at", func(m *model.InvitationModel) any { return m.UpdatedAt }},
+	{"expires_at", func(m *model.InvitationModel) any { return m.ExpiresAt }},
+	{"error_class", func(m *model.InvitationModel) any { return m.ErrorClass }},
+	{"first_error", func(m *model.InvitationModel) any { return m.FirstError }},
+	{"last_error", func(m *model.InvitationModel) any { return m.LastError }},
+	{"record_id", func(m *model.InvitationModel) any { return m.RecordID }},
+}
+
+// defaultExportColumns 不指定 columns 时导出的列，不含错误详情
+var defaultExportColumns = []string{
+	"id", "order_source", "order_id", "github_username", "github_email", "invitation_status", "created_at", "updated_at", "expires_at",
+}
+
+// exportInvitations 导出邀请记录：筛选参数同 ListParams（时间按 created_at，limit、cursor 不生效），
+// 另有 status（逗号分隔）、order_source、format（csv、xlsx、jsonl）、columns（逗号分隔）和 mask_email
+func exportInvitations(w http.ResponseWriter, r *http.Request) {
+	var (
+		err        error
+		statusCode = http.StatusOK
+		q          = r.URL.Query()
+		t          = query.InvitationModel
+	)
+	defer func() {
+		if err != nil {
+			writeError(w, r, statusCode, err)
+		}
+	}()
+
+	params, err := parseListParams(q)
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		return
+	}
+	format := q.Get("format")
+	if format == "" {
+		format = ExportCSV
+	}
+	if format != ExportCSV && format != ExportXLSX && format != ExportJSONL {
+		statusCode = http.StatusBadRequest
+		err = fmt.Errorf("invalid format %q, must be csv, xlsx or jsonl", format)
+		return
+	}
+	columns, err := parseExportColumns(q.Get("columns"))
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		return
+	}
+	maskEmail := q.Get("mask_email") == "true"
+
+	cols := listColumns{id: t.ID, at: t.CreatedAt, username: t.GithubUsername, email: t.GithubEmail}
+	conds := cols.filters(params)
+	if v := q.Get("status"); v != "" {
+		conds = append(conds, t.InvitationStatus.In(strings.Split(strings.ToUpper(v), ",")...))
+	}
+	if v := q.Get("order_source"); v != "" {
+		conds = append(conds, t.OrderSource.Eq(v))
+	}
+	_, order := cols.page(ListParams{Asc: params.Asc})
+	db := t.WithContext(r.Context()).Where(conds...).Order(order...).UnderlyingDB()
+	rows, err := db.Rows()
+	if err != nil {
+		statusCode = http.StatusInternalServerError
+		err = fmt.Errorf("export_query_error||err=%v", err)
+		return
+	}
+	defer rows.Close()
+
+	fileName := fmt.Sprintf("invitations-%s.%s", time.Now().Format("20060102-150405"), format)
+	w.Header().Set("Content-Type", exportContentType(format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, fileName))
+
+	out, err2 := newExportWriter(w, format, columns)
+	if err2 != nil {
+		logrus.WithError(err2).Error("export_write_error")
+		return
+	}
+	// 已开始输出后无法再返回错误状态码，出错时只记日志，文件会不完整
+	var cnt int
+	for rows.Next() {
+		var m model.InvitationModel
+		if err2 = db.ScanRows(rows, &m); err2 != nil {
+			break
+		}
+		if maskEmail {
+			m.GithubEmail = maskEmailAddress(m.GithubEmail)
+		}
+		values := make([]any, 0, len(columns))
+		for _, c := range columns {
+			values = append(values, c.value(&m))
+		}
+		if err2 = out.write(values); err2 != nil {
+			break
+		}
+		cnt++
+	}
+	if err2 == nil {
+		err2 = rows.Err()
+	}
+	if err3 := out.close(); err2 == nil {
+		err2 = err3
+	}
+	logger := logrus.WithFields(logrus.Fields{"format": format, "rows": cnt})
+	if err2 != nil {
+		logger.WithError(err2).Error("export_write_error")
+		return
+	}
+	logger.Info("export_done")
+}
+
+func parseExportColumns(s string) ([]exportColumn, error) {
+	names := defaultExportColumns
+	if s != "" {
+		names = strings.Split(s, ",")
+	}
+	columns := make([]exportColumn, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		i := indexExportColumn(name)
+		if i < 0 {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+		columns = append(columns, exportColumns[i])
+	}
+	return columns, nil
+}
+
+func indexExportColumn(name string) int {
+	for i, c := range exportColumns {
+		if c.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// maskEmailAddress 保留用户名的前两个字符和域名，如 oc****@github.com
+func maskEmailAddress(email string) string {
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok {
+		return email
+	}
+	runes := []rune(local)
+	return string(runes[:min(2, len(runes))]) + "****@" + domain
+}
+
+func exportContentType(format string) string {
+	switch format {
+	case ExportXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case ExportJSONL:
+		return "application/x-ndjson"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// exportWriter 按格式逐行写出，第一行为列名（jsonl 除外）
+type exportWriter struct {
+	write func(values []any) error
+	close func() error
+}
+
+func newExportWriter(w io.Writer, format string, columns []exportColumn) (*exportWriter, error) {
+	header := make([]any, 0, len(columns))
+	for _, c := range columns {
+		header = append(header, c.name)
+	}
+	switch format {
+	case ExportXLSX:
+		x, err := newXLSXWriter(w)
+		if err != nil {
+			return nil, err
+		}
+		if err = x.WriteRow(header); err != nil {
+			return nil, err
+		}
+		return &exportWriter{
+			write: func(values []any) error {
+				for i, v := range values {
+					values[i] = exportText(v)
+				}
+				return x.WriteRow(values)
+			},
+			close: x.Close,
+		}, nil
+	case ExportJSONL:
+		encoder := json.NewEncoder(w)
+		return &exportWriter{
+			write: func(values []any) error {
+				obj := make(map[string]any, len(values))
+				for i, v := range values {
+					if tm, ok := v.(time.Time); ok && isUnset(tm) {
+						v = nil
+					}
+					obj[columns[i].name] = v
+				}
+				return encoder.Encode(obj)
+			},
+			close: func() error { return nil },
+		}, nil
+	}
+	cw := csv.NewWriter(w)
+	record := make([]string, len(columns))
+	writeRecord := func(values []any) error {
+		for i, v := range values {
+			record[i] = fmt.Sprint(exportText(v))
+			// 防止表格软件把 = + - @ 开头的内容当作公式执行
+			if _, ok := v.(string); ok && record[i] != "" && strings.ContainsRune("=+-@", rune(record[i][0])) {
+				record[i] = "'" + record[i]
+			}
+		}
+		return cw.Write(record)
+	}
+	if err := writeRecord(header); err != nil {
+		return nil, err
+	}
+	return &exportWriter{
+		write: writeRecord,
+		close: func() error {
+			cw.Flush()
+			return cw.Error()
+		},
+	}, nil
+}
+
+// exportText 表格格式中的时间按本地时间 2006-01-02 15:04:05 输出，未设置的时间为空；数字保持为 int64
+func exportText(v any) any {
+	switch v := v.(type) {
+	case time.Time:
+		if isUnset(v) {
+			return ""
+		}
+		return v.Local().Format(time.DateTime)
+	case int64:
+		return v
+	case string:
+		return v
+	}
+	return fmt.Sprint(v)
+}
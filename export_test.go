@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+)
+
+func TestMaskEmailAddress(t *testing.T) {
+	tests := []struct {
+		email string
+		want  string
+	}{
+		{email: "octocat@github.com", want: "oc****@github.com"},
+		{email: "a@github.com", want: "a****@github.com"},
+		{email: "@github.com", want: "****@github.com"},
+		{email: "张三丰@example.com", want: "张三****@example.com"},
+		{email: "not-an-email", want: "not-an-email"},
+		{email: "", want: ""},
+	}
+	for _, tt := range tests {
+		if got := maskEmailAddress(tt.email); got != tt.want {
+			t.Errorf("maskEmailAddress(%q) = %q, want %q", tt.email, got, tt.want)
+		}
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{name: "plain", value: "octocat", want: "octocat"},
+		{name: "formula", value: "=HYPERLINK(\"x\")", want: "'=HYPERLINK(\"x\")"},
+		{name: "plus", value: "+1", want: "'+1"},
+		{name: "minus", value: "-1", want: "'-1"},
+		{name: "at", value: "@SUM(A1)", want: "'@SUM(A1)"},
+		{name: "formula char inside", value: "a=b", want: "a=b"},
+		{name: "empty", value: "", want: ""},
+		{name: "negative number stays a number", value: int64(-1), want: "-1"},
+		{name: "unset time", value: time.Time{}, want: ""},
+		{name: "time", value: time.Date(2026, 3, 1, 12, 30, 0, 0, time.Local), want: "2026-03-01 12:30:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			// 单列的空值在 CSV 中是空行，读取时会被跳过，因此加一列行号
+			w, err := newExportWriter(&buf, ExportCSV, []exportColumn{{name: "row"}, {name: "value"}})
+			if err != nil {
+				t.Fatalf("newExportWriter() error = %v", err)
+			}
+			if err = w.write([]any{int64(1), tt.value}); err != nil {
+				t.Fatalf("write() error = %v", err)
+			}
+			if err = w.close(); err != nil {
+				t.Fatalf("close() error = %v", err)
+			}
+			records, err := csv.NewReader(&buf).ReadAll()
+			if err != nil {
+				t.Fatalf("read csv error = %v", err)
+			}
+			if len(records) != 2 || records[0][1] != "value" {
+				t.Fatalf("records = %q, want header and one row", records)
+			}
+			if got := records[1][1]; got != tt.want {
+				t.Errorf("cell = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
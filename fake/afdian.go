@@ -0,0 +1,158 @@
+package fake
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+const AfdianOrderStatusPaid = 2
+
+// AfdianOrder 爱发电订单，字段与 webhook 和查询订单接口一致
+type AfdianOrder struct {
+	OutTradeNo  string `json:"out_trade_no"`
+	UserID      string `json:"user_id"`
+	PlanID      string `json:"plan_id"`
+	Month       int    `json:"month"`
+	TotalAmount string `json:"total_amount"`
+	Status      int    `json:"status"`
+	Remark      string `json:"remark"`
+}
+
+// Afdian 爱发电开放接口的替身：POST /api/open/query-order，
+// 并用自己的密钥给 webhook 签名，服务端配置 PublicKeyPEM 即可验签
+type Afdian struct {
+	UserID string
+	Token  string
+
+	key    *rsa.PrivateKey
+	mu     sync.RWMutex
+	orders map[string]AfdianOrder
+}
+
+func NewAfdian(userID, token string, orders ...AfdianOrder) (*Afdian, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	a := &Afdian{UserID: userID, Token: token, key: key, orders: make(map[string]AfdianOrder)}
+	for _, o := range orders {
+		a.orders[o.OutTradeNo] = o
+	}
+	return a, nil
+}
+
+// PublicKeyPEM 对应 config.yaml 中的 afdian.public_key
+func (a *Afdian) PublicKeyPEM() string {
+	der, _ := x509.MarshalPKIXPublicKey(&a.key.PublicKey)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+// Put 新增或覆盖订单
+func (a *Afdian) Put(o AfdianOrder) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.orders[o.OutTradeNo] = o
+}
+
+func (a *Afdian) order(outTradeNo string) (AfdianOrder, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	o, ok := a.orders[outTradeNo]
+	return o, ok
+}
+
+// Webhook 生成带签名的 webhook 请求体
+func (a *Afdian) Webhook(o AfdianOrder) ([]byte, error) {
+	digest := sha256.Sum256([]byte(o.OutTradeNo + o.UserID + o.PlanID + o.TotalAmount))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, a.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(map[string]any{
+		"ec": 200,
+		"em": "ok",
+		"data": map[string]any{
+			"type":  "order",
+			"order": o,
+			"sign":  base64.StdEncoding.EncodeToString(signature),
+		},
+	})
+}
+
+// Push 把已有订单以 webhook 推送到 url
+func (a *Afdian) Push(ctx context.Context, url, outTradeNo string) error {
+	o, ok := a.order(outTradeNo)
+	if !ok {
+		return fmt.Errorf("order %s not found", outTradeNo)
+	}
+	body, err := a.Webhook(o)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("webhook returned %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (a *Afdian) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != "/api/open/query-order" {
+		http.NotFound(w, r)
+		return
+	}
+	var req struct {
+		UserID string `json:"user_id"`
+		Params string `json:"params"`
+		TS     int64  `json:"ts"`
+		Sign   string `json:"sign"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, map[string]any{"ec": 400, "em": "bad request"})
+		return
+	}
+	sum := md5.Sum([]byte(fmt.Sprintf("%sparams%sts%duser_id%s", a.Token, req.Params, req.TS, req.UserID)))
+	if req.UserID != a.UserID || req.Sign != hex.EncodeToString(sum[:]) {
+		writeJSON(w, map[string]any{"ec": 400005, "em": "sign validation failed"})
+		return
+	}
+	var params struct {
+		OutTradeNo string `json:"out_trade_no"`
+	}
+	_ = json.Unmarshal([]byte(req.Params), &params)
+	list := []AfdianOrder{}
+	if o, ok := a.order(params.OutTradeNo); ok {
+		list = append(list, o)
+	}
+	writeJSON(w, map[string]any{
+		"ec": 200,
+		"em": "",
+		"data": map[string]any{
+			"list":        list,
+			"total_count": len(list),
+			"total_page":  1,
+		},
+	})
+}
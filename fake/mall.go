@@ -0,0 +1,80 @@
+// Package fake 提供外部服务的本地替身，用于测试和本地联调
+package fake
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+const (
+	MallPayStatusUnpaid = 0
+	MallPayStatusPaid   = 1
+
+	MallRefundStatusNone     = 0
+	MallRefundStatusRefunded = 2
+)
+
+// MallOrder 会员购订单，字段与订单详情接口的 data 一致
+type MallOrder struct {
+	OrderID      int64  `json:"orderId"`
+	OrderStatus  int    `json:"orderStatus"`
+	StatusName   string `json:"statusName"`
+	PayStatus    int    `json:"payStatus"`
+	RefundStatus int    `json:"refundStatus"`
+	ItemsID      int64  `json:"itemsId"`
+}
+
+// Mall 会员购商家后台订单详情接口的替身：GET /mall-seller/order/detail?orderId=
+type Mall struct {
+	// Cookie 非空时校验请求的 Cookie 头
+	Cookie string
+
+	mu     sync.RWMutex
+	orders map[int64]MallOrder
+}
+
+func NewMall(orders ...MallOrder) *Mall {
+	m := &Mall{orders: make(map[int64]MallOrder)}
+	for _, o := range orders {
+		m.orders[o.OrderID] = o
+	}
+	return m
+}
+
+// Put 新增或覆盖订单，可用来模拟付款、退款
+func (m *Mall) Put(o MallOrder) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.orders[o.OrderID] = o
+}
+
+func (m *Mall) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet || r.URL.Path != "/mall-seller/order/detail" {
+		http.NotFound(w, r)
+		return
+	}
+	if m.Cookie != "" && r.Header.Get("Cookie") != m.Cookie {
+		writeJSON(w, map[string]any{"code": -101, "message": "账号未登录"})
+		return
+	}
+	orderID, err := strconv.ParseInt(r.URL.Query().Get("orderId"), 10, 64)
+	if err != nil {
+		writeJSON(w, map[string]any{"code": -400, "message": "请求错误"})
+		return
+	}
+	m.mu.RLock()
+	o, ok := m.orders[orderID]
+	m.mu.RUnlock()
+	if !ok {
+		writeJSON(w, map[string]any{"code": -404, "message": "订单不存在"})
+		return
+	}
+	writeJSON(w, map[string]any{"code": 0, "message": "success", "data": o})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -8,49 +8,112 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 
 	lark "github.com/larksuite/oapi-sdk-go/v3"
 	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
 	larksheets "github.com/larksuite/oapi-sdk-go/v3/service/sheets/v3"
 	"github.com/spf13/cast"
-)
-
-const (
-	feishuSpreadsheet = `Xhs2sax3GhvF3rt7atLcjxl1nwd`
-	feishuAppID       = "cli_a749705063fa100c"
+	"github.com/spf13/viper"
 )
 
 var (
 	feishuAppSecret string
 )
 
+// FeishuConfig 对应 config.yaml 中的 feishu，app secret 仍从环境变量读取
+type FeishuConfig struct {
+	// Domain 开放平台域名，飞书为 https://open.feishu.cn，Lark 国际版为 https://open.larksuite.com
+	Domain      string `mapstructure:"domain"`
+	AppID       string `mapstructure:"app_id"`
+	Spreadsheet string `mapstructure:"spreadsheet"`
+	// SheetID、SheetTitle 用于选择工作表，都为空时取第一个
+	SheetID    string `mapstructure:"sheet_id"`
+	SheetTitle string `mapstructure:"sheet_title"`
+}
+
+func feishuConfig() FeishuConfig {
+	c := FeishuConfig{
+		Domain:      viper.GetString("feishu.domain"),
+		AppID:       viper.GetString("feishu.app_id"),
+		Spreadsheet: viper.GetString("feishu.spreadsheet"),
+		SheetID:     viper.GetString("feishu.sheet_id"),
+		SheetTitle:  viper.GetString("feishu.sheet_title"),
+	}
+	if c.Domain == "" {
+		c.Domain = lark.FeishuBaseUrl
+	}
+	c.Domain = strings.TrimRight(c.Domain, "/")
+	return c
+}
+
+// feishuHTTPClient 直接调用和 SDK 调用都经过它，记录各接口的耗时
+var feishuHTTPClient = newInstrumentedClient(upstreamFeishu, 0, feishuEndpoint)
+
+func newFeishuClient() *lark.Client {
+	conf := feishuConfig()
+	return lark.NewClient(conf.AppID, feishuAppSecret, lark.WithOpenBaseUrl(conf.Domain), lark.WithHttpClient(feishuHTTPClient))
+}
+
+// tenant token 有效期约 2 小时，缓存到过期前 feishuTokenMargin，长时间的运行中每次使用前取一次即可
+const feishuTokenMargin = 5 * time.Minute
+
+var feishuToken struct {
+	mu        sync.Mutex
+	value     string
+	expiresAt time.Time
+}
+
 func acquireFeishuTenantAccessToken() (string, error) {
-	url := "https://open.feishu.cn/open-apis/auth/v3/tenant_access_token/internal"
+	feishuToken.mu.Lock()
+	defer feishuToken.mu.Unlock()
+	if feishuToken.value != "" && time.Now().Before(feishuToken.expiresAt) {
+		return feishuToken.value, nil
+	}
+	token, expire, err := fetchFeishuTenantAccessToken(context.Background())
+	if err != nil {
+		return "", err
+	}
+	feishuToken.value = token
+	feishuToken.expiresAt = time.Now().Add(time.Duration(expire)*time.Second - feishuTokenMargin)
+	return token, nil
+}
+
+// acquireFeishuTenantAccessTokenWithContext 不使用缓存，用于检查应用凭证
+func acquireFeishuTenantAccessTokenWithContext(ctx context.Context) (string, error) {
+	token, _, err := fetchFeishuTenantAccessToken(ctx)
+	return token, err
+}
+
+// fetchFeishuTenantAccessToken 返回 token 和剩余有效秒数
+func fetchFeishuTenantAccessToken(ctx context.Context) (string, int, error) {
+	conf := feishuConfig()
+	url := conf.Domain + "/open-apis/auth/v3/tenant_access_token/internal"
 
 	payload, err := json.Marshal(map[string]any{
-		"app_id":     feishuAppID,
+		"app_id":     conf.AppID,
 		"app_secret": feishuAppSecret,
 	})
 	if err != nil {
-		return "", fmt.Errorf("marshal payload error||err=%w", err)
+		return "", 0, fmt.Errorf("marshal payload error||err=%w", err)
 	}
 
-	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(string(payload)))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(string(payload)))
 	if err != nil {
-		return "", fmt.Errorf("new request error||err=%w", err)
+		return "", 0, fmt.Errorf("new request error||err=%w", err)
 	}
 	req.Header.Add("Content-Type", "application/json; charset=utf-8")
-	req.Header.Add("Host", "open.feishu.cn")
 
-	res, err := http.DefaultClient.Do(req)
+	res, err := feishuHTTPClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("do request error||err=%w", err)
+		return "", 0, fmt.Errorf("do request error||err=%w", err)
 	}
 	defer res.Body.Close()
 
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
-		return "", fmt.Errorf("read body error||err=%w", err)
+		return "", 0, fmt.Errorf("read body error||err=%w", err)
 	}
 
 	type APIResponse struct {
@@ -61,21 +124,21 @@ func acquireFeishuTenantAccessToken() (string, error) {
 	}
 	var resp APIResponse
 	if err = json.Unmarshal(body, &resp); err != nil {
-		return "", fmt.Errorf("bind response error||resp=%s||err=%w", string(body), err)
+		return "", 0, fmt.Errorf("bind response error||resp=%s||err=%w", string(body), err)
 	}
 	if resp.Code != 0 {
-		return "", fmt.Errorf("response code non-zero||resp=%s||err=%w", string(body), err)
+		return "", 0, fmt.Errorf("response code non-zero||resp=%s||err=%w", string(body), err)
 	}
-	return resp.TenantAccessToken, nil
+	return resp.TenantAccessToken, resp.Expire, nil
 }
 
 // SDK 使用文档：https://open.feishu.cn/document/uAjLw4CM/ukTMukTMukTM/server-side-sdk/golang-sdk-guide/preparations
 // 复制该 Demo 后, 需要将 "YOUR_APP_ID", "YOUR_APP_SECRET" 替换为自己应用的 APP_ID, APP_SECRET.
 // 以下示例代码默认根据文档示例值填充，如果存在代码问题，请在 API 调试台填上相关必要参数后再复制代码使用
 func GetSheets() (*larksheets.QuerySpreadsheetSheetResp, error) {
-	client := lark.NewClient(feishuAppID, feishuAppSecret)
+	client := newFeishuClient()
 	req := larksheets.NewQuerySpreadsheetSheetReqBuilder().
-		SpreadsheetToken(feishuSpreadsheet).
+		SpreadsheetToken(feishuConfig().Spreadsheet).
 		Build()
 
 	feishuTenantAccessToken, err := acquireFeishuTenantAccessToken()
@@ -94,52 +157,135 @@ func GetSheets() (*larksheets.QuerySpreadsheetSheetResp, error) {
 	return resp, nil
 }
 
-func FirstSheet(resp *larksheets.QuerySpreadsheetSheetResp) (string, error) {
+// SelectSheet 按配置的 sheet_id 或 sheet_title 选择工作表，未配置时取第一个
+func SelectSheet(resp *larksheets.QuerySpreadsheetSheetResp) (*larksheets.Sheet, error) {
 	if len(resp.Data.Sheets) == 0 {
-		return "", errors.New("no sheets")
+		return nil, errors.New("no sheets")
 	}
-	if resp.Data.Sheets[0].SheetId == nil {
-		return "", errors.New("sheetID is nil")
+	conf := feishuConfig()
+	if conf.SheetID == "" && conf.SheetTitle == "" {
+		if resp.Data.Sheets[0].SheetId == nil {
+			return nil, errors.New("sheetID is nil")
+		}
+		return resp.Data.Sheets[0], nil
 	}
-	return *resp.Data.Sheets[0].SheetId, nil
+	for _, sheet := range resp.Data.Sheets {
+		if sheet.SheetId == nil {
+			continue
+		}
+		if conf.SheetID != "" && *sheet.SheetId == conf.SheetID {
+			return sheet, nil
+		}
+		if conf.SheetTitle != "" && sheet.Title != nil && *sheet.Title == conf.SheetTitle {
+			return sheet, nil
+		}
+	}
+	return nil, fmt.Errorf("sheet not found||sheetID=%s||sheetTitle=%s", conf.SheetID, conf.SheetTitle)
 }
 
 type Range struct {
+	// Row 表格中的行号，从 1 开始；多维表格来源为 0
+	Row            int
 	OrderID        int64
 	GithubUsername string
 	GithubEmail    string
+	// RecordID 多维表格的记录 ID，用于回写邀请状态；普通表格为空
+	RecordID string
+	// ExpiresAt 表格中填写的到期时间，为零值时按商品时长计算或永久有效
+	ExpiresAt time.Time
+	// OrderSource 订单来源，表格来源为空，即会员购订单
+	OrderSource string
+}
+
+func (r Range) orderSource() string {
+	if r.OrderSource == "" {
+		return OrderSourceBilibili
+	}
+	return r.OrderSource
 }
 
-func SheetRangeContent(start, end string) ([]Range, error) {
+// 单次读取的行数，避免超出飞书单次请求的单元格数和响应大小限制
+const sheetChunkRows = 2000
+
+func SheetRangeContent(start, end string) (r []Range, invalid []InvalidRow, err error) {
+	err = SheetRangeEach(context.Background(), start, end, func(_ context.Context, rows []Range, bad []InvalidRow) error {
+		r = append(r, rows...)
+		invalid = append(invalid, bad...)
+		return nil
+	})
+	return r, invalid, err
+}
+
+// SheetRangeEach 按行分块读取 start:end，每读完一块就交给 fn 处理。
+// end 不带行号（如 "C"）时以工作表元数据中的总行数作为结束行
+func SheetRangeEach(ctx context.Context, start, end string, fn func(context.Context, []Range, []InvalidRow) error) error {
+	startCol, startRow := splitCell(start)
+	endCol, endRow := splitCell(end)
+	if startCol == "" || endCol == "" {
+		return fmt.Errorf("invalid range||start=%s||end=%s", start, end)
+	}
+	if startRow == 0 {
+		startRow = 1
+	}
+
 	sheets, err := GetSheets()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	sheetID, err := FirstSheet(sheets)
+	sheet, err := SelectSheet(sheets)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	queryRange := fmt.Sprintf(`%s!%s:%s`, sheetID, start, end)
-	fullURL := fmt.Sprintf("https://open.feishu.cn/open-apis/sheets/v2/spreadsheets/%s/values/%s", feishuSpreadsheet, queryRange)
-	req, err := http.NewRequest(http.MethodGet, fullURL, nil)
-	if err != nil {
-		return nil, err
+	if endRow == 0 {
+		if sheet.GridProperties == nil || sheet.GridProperties.RowCount == nil {
+			return fmt.Errorf("sheet row count unknown||sheetID=%s", *sheet.SheetId)
+		}
+		endRow = *sheet.GridProperties.RowCount
 	}
-	feishuTenantAccessToken, err := acquireFeishuTenantAccessToken()
+
+	for from := startRow; from <= endRow; from += sheetChunkRows {
+		// 每块之间可能处理很久，每次读取前重新取 token，过期前会自动换新
+		feishuTenantAccessToken, err := acquireFeishuTenantAccessToken()
+		if err != nil {
+			return fmt.Errorf("error acquiring tenant access token||err=%w", err)
+		}
+		to := min(from+sheetChunkRows-1, endRow)
+		queryRange := fmt.Sprintf(`%s!%s%d:%s%d`, *sheet.SheetId, startCol, from, endCol, to)
+		values, err := sheetValues(ctx, feishuTenantAccessToken, queryRange)
+		if err != nil {
+			return fmt.Errorf("read sheet values error||range=%s||err=%w", queryRange, err)
+		}
+		r, invalid := parseContent(values, from)
+		if err = fn(ctx, r, invalid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sheetValues(ctx context.Context, feishuTenantAccessToken, queryRange string) ([][]any, error) {
+	conf := feishuConfig()
+	fullURL := fmt.Sprintf("%s/open-apis/sheets/v2/spreadsheets/%s/values/%s", conf.Domain, conf.Spreadsheet, queryRange)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error acquiring tenant access token||err=%w", err)
+		return nil, err
 	}
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", feishuTenantAccessToken))
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := feishuHTTPClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
-	bytes, err := io.ReadAll(resp.Body)
 	defer resp.Body.Close()
+	bytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
 
 	type APIResponse struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
 		Data struct {
 			ValueRange struct {
 				Values [][]any `json:"values"`
@@ -150,43 +296,78 @@ func SheetRangeContent(start, end string) ([]Range, error) {
 	if err := json.Unmarshal(bytes, &apiResponse); err != nil {
 		return nil, err
 	}
+	if apiResponse.Code != 0 {
+		return nil, fmt.Errorf("response code non-zero||code=%d||msg=%s", apiResponse.Code, apiResponse.Msg)
+	}
+	return apiResponse.Data.ValueRange.Values, nil
+}
 
-	return parseContent(apiResponse.Data.ValueRange.Values)
+// splitCell 拆分单元格坐标，例如 A2 -> ("A", 2)，C -> ("C", 0)
+func splitCell(cell string) (col string, row int) {
+	i := strings.IndexAny(cell, "0123456789")
+	if i < 0 {
+		return strings.ToUpper(cell), 0
+	}
+	return strings.ToUpper(cell[:i]), cast.ToInt(cell[i:])
 }
 
-func parseContent(values [][]any) (r []Range, err error) {
-	for _, v := range values {
-		data := Range{
-			OrderID:        cast.ToInt64(v[0]),
-			GithubUsername: cast.ToString(v[1]),
+// parseContent 逐行校验，格式错误的行放进 invalid 返回，不影响其他行
+func parseContent(values [][]any, firstRow int) (r []Range, invalid []InvalidRow) {
+	for i, v := range values {
+		// 第 4 列为可选的到期时间
+		cells := make([]any, 4)
+		copy(cells, v)
+		raw := rawRow{
+			OrderID:        cells[0],
+			GithubUsername: cast.ToString(cells[1]),
+			ExpiresAt:      cells[3],
 		}
-		if _, err = cast.ToStringE(v[2]); err == nil {
-			data.GithubEmail = cast.ToString(v[2])
-		} else {
-			type CellValue struct {
-				Link string `json:"link"`
-				Text string `json:"text"`
-				Type string `json:"type"`
-			}
-			cellData, ok := v[2].([]interface{})
-			if !ok || len(cellData) == 0 {
-				return nil, fmt.Errorf("invalid cell data format")
-			}
-			cellMap, ok := cellData[0].(map[string]any)
-			if !ok {
-				return nil, fmt.Errorf("invalid cell map format")
-			}
-			cellBytes, err := json.Marshal(cellMap)
-			if err != nil {
-				return nil, fmt.Errorf("marshal cell map error, err=%v", err)
-			}
-			var cell CellValue
-			if err = json.Unmarshal(cellBytes, &cell); err != nil {
-				return nil, fmt.Errorf("unmarshal cell error, err=%v", err)
-			}
-			data.GithubEmail = cell.Text
+		if firstRow > 0 {
+			raw.Row = firstRow + i
 		}
-		r = append(r, data)
+		email, err := cellText(cells[2])
+		if err != nil {
+			raw.GithubEmail = fmt.Sprintf("%v", cells[2])
+			invalid = append(invalid, raw.invalid(fmt.Sprintf("invalid github email cell: %v", err)))
+			continue
+		}
+		raw.GithubEmail = email
+		data, bad, ok := validateRow(raw)
+		if bad != nil {
+			invalid = append(invalid, *bad)
+		}
+		if ok {
+			r = append(r, data)
+		}
+	}
+	return r, invalid
+}
+
+// cellText 单元格可能是纯文本，也可能是带链接的富文本（如邮箱），统一取出文本
+func cellText(v any) (string, error) {
+	if s, err := cast.ToStringE(v); err == nil {
+		return s, nil
+	}
+	type CellValue struct {
+		Link string `json:"link"`
+		Text string `json:"text"`
+		Type string `json:"type"`
+	}
+	cellData, ok := v.([]interface{})
+	if !ok || len(cellData) == 0 {
+		return "", fmt.Errorf("invalid cell data format")
+	}
+	cellMap, ok := cellData[0].(map[string]any)
+	if !ok {
+		return "", fmt.Errorf("invalid cell map format")
+	}
+	cellBytes, err := json.Marshal(cellMap)
+	if err != nil {
+		return "", fmt.Errorf("marshal cell map error, err=%v", err)
+	}
+	var cell CellValue
+	if err = json.Unmarshal(cellBytes, &cell); err != nil {
+		return "", fmt.Errorf("unmarshal cell error, err=%v", err)
 	}
-	return r, nil
+	return cell.Text, nil
 }
@@ -13,6 +13,9 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// githubHTTPClient 记录各接口的耗时和剩余额度，见 metrics.go
+var githubHTTPClient = newInstrumentedClient(upstreamGithub, 0, githubEndpoint)
+
 type InviteResponse struct {
 	Message string `json:"message"`
 	Errors  []struct {
@@ -25,6 +28,20 @@ type InviteResponse struct {
 	Status           string `json:"status"`
 }
 
+// rateLimited 频率限制与参数错误都可能是 422，只能按错误信息区分
+func (r InviteResponse) rateLimited() bool {
+	messages := []string{r.Message}
+	for _, e := range r.Errors {
+		messages = append(messages, e.Message)
+	}
+	for _, m := range messages {
+		if strings.Contains(strings.ToLower(m), "rate limit") {
+			return true
+		}
+	}
+	return false
+}
+
 func CheckIfUserIsMember(ctx context.Context, username string) (bool, error) {
 	url := "https://api.github.com/orgs/Nicknamezz00-organization/members/" + username
 	req, err := http.NewRequest("GET", url, nil)
@@ -35,7 +52,7 @@ func CheckIfUserIsMember(ctx context.Context, username string) (bool, error) {
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", githubPersonalAccessToken))
 	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := githubHTTPClient.Do(req)
 	if err != nil {
 		return false, err
 	}
@@ -54,15 +71,32 @@ func CheckIfUserIsMember(ctx context.Context, username string) (bool, error) {
 	}
 }
 
-var ErrAlreadyInvited = errors.New("already invited, skip")
+var (
+	ErrAlreadyInvited = errors.New("already invited, skip")
+	// ErrGithubUserNotFound GitHub 用户名不存在，重试也不会成功
+	ErrGithubUserNotFound = errors.New("github user not found")
+	// ErrInviteRejected GitHub 拒绝了邀请参数（422），如邮箱无效
+	ErrInviteRejected = errors.New("invitation rejected by github")
+	// ErrInviteRateLimited 超出组织的邀请频率限制（同样是 422），稍后重试即可
+	ErrInviteRateLimited = errors.New("over github invitation rate limit")
+)
 
+// Invite 按邮箱邀请；没有邮箱时按 GitHub 用户 ID 邀请
 func Invite(username, email string) error {
 	url := "https://api.github.com/orgs/Nicknamezz00-organization/invitations"
 	data := map[string]any{
-		"email":    email,
 		"role":     "direct_member",
 		"team_ids": []int64{},
 	}
+	if email != "" {
+		data["email"] = email
+	} else {
+		id, err := GetUserID(username)
+		if err != nil {
+			return err
+		}
+		data["invitee_id"] = id
+	}
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return err
@@ -76,7 +110,7 @@ func Invite(username, email string) error {
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", githubPersonalAccessToken))
 	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := githubHTTPClient.Do(req)
 	if err != nil {
 		return err
 	}
@@ -87,11 +121,173 @@ func Invite(username, email string) error {
 	_ = json.Unmarshal(bytes, &r)
 
 	if resp.StatusCode != http.StatusCreated {
-		if strings.Contains(r.Errors[0].Message, "already a part of this organization") {
+		if len(r.Errors) > 0 && strings.Contains(r.Errors[0].Message, "already a part of this organization") {
 			logrus.Debugf("%s is already a part of this organization", username)
 			return ErrAlreadyInvited
 		}
+		if r.rateLimited() {
+			return fmt.Errorf("[MUST NOTICE]||json=%v||code=%v||resp=%s||err=%w", string(jsonData), resp.StatusCode, string(bytes), ErrInviteRateLimited)
+		}
+		if resp.StatusCode == http.StatusUnprocessableEntity {
+			return fmt.Errorf("[MUST NOTICE]||json=%v||code=%v||resp=%s||err=%w", string(jsonData), resp.StatusCode, string(bytes), ErrInviteRejected)
+		}
 		return fmt.Errorf("[MUST NOTICE]||req=%+v||json=%v||code=%v||resp=%s", req, string(jsonData), resp.StatusCode, string(bytes))
 	}
 	return nil
 }
+
+// GetUserID 查询 GitHub 用户的数字 ID
+func GetUserID(username string) (int64, error) {
+	url := "https://api.github.com/users/" + username
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", githubPersonalAccessToken))
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := githubHTTPClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	bytes, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode == http.StatusNotFound {
+		return 0, fmt.Errorf("get user error||username=%s||err=%w", username, ErrGithubUserNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("get user error||username=%s||code=%v||resp=%s", username, resp.StatusCode, string(bytes))
+	}
+	var user struct {
+		ID int64 `json:"id"`
+	}
+	if err = json.Unmarshal(bytes, &user); err != nil {
+		return 0, fmt.Errorf("bind user error||resp=%s||err=%w", string(bytes), err)
+	}
+	return user.ID, nil
+}
+
+// ErrNothingToRemove 用户既不是成员，也没有待接受的邀请
+var ErrNothingToRemove = errors.New("no membership or pending invitation")
+
+// RemoveMember 移出组织；不是成员时撤回按用户名或邮箱匹配的待接受邀请，
+// 按邮箱发出的邀请在接受前没有 membership，只能这样撤回。两者都没有时返回 ErrNothingToRemove
+func RemoveMember(ctx context.Context, username, email string) error {
+	resp, err := githubDo(ctx, http.MethodDelete, "https://api.github.com/orgs/Nicknamezz00-organization/memberships/"+username)
+	if err != nil {
+		return err
+	}
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		return nil
+	case http.StatusNotFound:
+	default:
+		return fmt.Errorf("remove member error||username=%s||code=%v||resp=%s", username, resp.StatusCode, resp.body)
+	}
+
+	id, err := findPendingInvitation(ctx, username, email)
+	if err != nil {
+		return err
+	}
+	if id == 0 {
+		return fmt.Errorf("remove member error||username=%s||email=%s||err=%w", username, email, ErrNothingToRemove)
+	}
+	resp, err = githubDo(ctx, http.MethodDelete, fmt.Sprintf("https://api.github.com/orgs/Nicknamezz00-organization/invitations/%d", id))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("cancel invitation error||invitationID=%d||code=%v||resp=%s", id, resp.StatusCode, resp.body)
+	}
+	return nil
+}
+
+// findPendingInvitation 翻页查找待接受的邀请，没有时返回 0
+func findPendingInvitation(ctx context.Context, username, email string) (int64, error) {
+	const perPage = 100
+	for page := 1; ; page++ {
+		resp, err := githubGet(ctx, fmt.Sprintf("https://api.github.com/orgs/Nicknamezz00-organization/invitations?per_page=%d&page=%d", perPage, page))
+		if err != nil {
+			return 0, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return 0, fmt.Errorf("list invitations error||code=%v||resp=%s", resp.StatusCode, resp.body)
+		}
+		var invitations []struct {
+			ID    int64  `json:"id"`
+			Login string `json:"login"`
+			Email string `json:"email"`
+		}
+		if err = json.Unmarshal([]byte(resp.body), &invitations); err != nil {
+			return 0, fmt.Errorf("bind invitations error||resp=%s||err=%w", resp.body, err)
+		}
+		for _, inv := range invitations {
+			if (inv.Login != "" && strings.EqualFold(inv.Login, username)) ||
+				(email != "" && strings.EqualFold(inv.Email, email)) {
+				return inv.ID, nil
+			}
+		}
+		if len(invitations) < perPage {
+			return 0, nil
+		}
+	}
+}
+
+// requiredGithubScope 邀请、移出成员都需要的 classic PAT 权限
+const requiredGithubScope = "admin:org"
+
+// CheckGithubCredential 校验 PAT 有效且有管理组织邀请的权限。
+// classic PAT 通过 X-OAuth-Scopes 判断；fine-grained PAT 没有该响应头，改为试读一次待接受的邀请
+func CheckGithubCredential(ctx context.Context) error {
+	resp, err := githubGet(ctx, "https://api.github.com/user")
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("check credential error||code=%v||resp=%s", resp.StatusCode, resp.body)
+	}
+	if scopes, ok := resp.Header["X-Oauth-Scopes"]; ok {
+		for _, scope := range strings.Split(strings.Join(scopes, ","), ",") {
+			if strings.TrimSpace(scope) == requiredGithubScope {
+				return nil
+			}
+		}
+		return fmt.Errorf("check credential error||scopes=%s||missing scope %s", strings.Join(scopes, ","), requiredGithubScope)
+	}
+	resp, err = githubGet(ctx, "https://api.github.com/orgs/Nicknamezz00-organization/invitations?per_page=1")
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("check credential error||no access to org invitations||code=%v||resp=%s", resp.StatusCode, resp.body)
+	}
+	return nil
+}
+
+type githubResponse struct {
+	*http.Response
+	body string
+}
+
+func githubGet(ctx context.Context, url string) (*githubResponse, error) {
+	return githubDo(ctx, http.MethodGet, url)
+}
+
+func githubDo(ctx context.Context, method, url string) (*githubResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", githubPersonalAccessToken))
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := githubHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	bytes, _ := io.ReadAll(resp.Body)
+	return &githubResponse{Response: resp, body: string(bytes)}, nil
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+const (
+	HealthOK          = "ok"
+	HealthFail        = "fail"
+	HealthUnavailable = "unavailable"
+)
+
+// ReadinessResponse GET /readyz 的响应，Checks 为每项依赖的 ok 或 fail，任一失败时 Status 为 unavailable，状态码为 503；
+// 探针不需要认证，失败原因只写日志，完整结果见需要认证的 GET /health
+type ReadinessResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// CheckResult 单项依赖检查的结果，LastError 为最近一次失败的原因，检查恢复后仍保留
+type CheckResult struct {
+	OK          bool       `json:"ok"`
+	LatencyMS   int64      `json:"latency_ms"`
+	CheckedAt   time.Time  `json:"checked_at"`
+	Error       string     `json:"error,omitempty"`
+	LastError   string     `json:"last_error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+}
+
+// HealthResponse GET /health 的响应，状态同 /readyz，但总是返回 200
+type HealthResponse struct {
+	Status string                  `json:"status"`
+	Checks map[string]*CheckResult `json:"checks"`
+}
+
+// dependencyCheck 一项依赖检查，结果缓存 ttl，避免探针频繁调用飞书和 GitHub
+type dependencyCheck struct {
+	name  string
+	check func(ctx context.Context) error
+
+	mu     sync.Mutex
+	result CheckResult
+}
+
+// readiness 依次为 Postgres、飞书 tenant token、GitHub PAT
+type readiness struct {
+	checks  []*dependencyCheck
+	timeout time.Duration
+	ttl     time.Duration
+}
+
+func newReadiness(db *gorm.DB) *readiness {
+	h := &readiness{
+		timeout: viper.GetDuration("health.timeout"),
+		ttl:     viper.GetDuration("health.cache_ttl"),
+	}
+	if h.timeout <= 0 {
+		h.timeout = 5 * time.Second
+	}
+	if h.ttl <= 0 {
+		h.ttl = 15 * time.Second
+	}
+	h.checks = []*dependencyCheck{
+		{name: "postgres", check: func(ctx context.Context) error {
+			sqlDB, err := db.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		}},
+		{name: "feishu", check: func(ctx context.Context) error {
+			_, err := acquireFeishuTenantAccessTokenWithContext(ctx)
+			return err
+		}},
+		{name: "github", check: CheckGithubCredential},
+	}
+	return h
+}
+
+// run 结果未过期时直接返回缓存；同一项检查同时只有一个在执行，失败时记录原因
+func (c *dependencyCheck) run(ctx context.Context, timeout, ttl time.Duration) CheckResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.result.CheckedAt.IsZero() && time.Since(c.result.CheckedAt) < ttl {
+		return c.result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	start := time.Now()
+	err := c.check(ctx)
+	c.result.CheckedAt = time.Now()
+	c.result.LatencyMS = c.result.CheckedAt.Sub(start).Milliseconds()
+	c.result.OK = err == nil
+	c.result.Error = ""
+	if err != nil {
+		c.result.Error = err.Error()
+		c.result.LastError = err.Error()
+		checkedAt := c.result.CheckedAt
+		c.result.LastErrorAt = &checkedAt
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"check":     c.name,
+			"latencyMS": c.result.LatencyMS,
+		}).Warn("readiness_check_failed")
+	}
+	return c.result
+}
+
+// healthz 存活探针，只要进程能响应就返回 200
+func healthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, r, http.StatusOK, map[string]string{"status": HealthOK})
+}
+
+// runAll 并发执行各项检查，结果与 h.checks 一一对应
+func (h *readiness) runAll(ctx context.Context) []CheckResult {
+	var (
+		wg      sync.WaitGroup
+		results = make([]CheckResult, len(h.checks))
+	)
+	for i, c := range h.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// 结果会缓存给后续探针，不随本次请求断开而取消
+			results[i] = c.run(context.WithoutCancel(ctx), h.timeout, h.ttl)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// readyz 就绪探针，只返回每项的 ok 或 fail
+func (h *readiness) readyz(w http.ResponseWriter, r *http.Request) {
+	results := h.runAll(r.Context())
+	resp := ReadinessResponse{Status: HealthOK, Checks: make(map[string]string, len(h.checks))}
+	statusCode := http.StatusOK
+	for i, c := range h.checks {
+		resp.Checks[c.name] = HealthOK
+		if !results[i].OK {
+			resp.Checks[c.name] = HealthFail
+			resp.Status = HealthUnavailable
+			statusCode = http.StatusServiceUnavailable
+		}
+	}
+	writeJSON(w, r, statusCode, resp)
+}
+
+// health 每项检查的耗时和最近一次错误，包含上游的错误内容，需要认证
+func (h *readiness) health(w http.ResponseWriter, r *http.Request) {
+	results := h.runAll(r.Context())
+	resp := HealthResponse{Status: HealthOK, Checks: make(map[string]*CheckResult, len(h.checks))}
+	for i, c := range h.checks {
+		resp.Checks[c.name] = &results[i]
+		if !results[i].OK {
+			resp.Status = HealthUnavailable
+		}
+	}
+	writeJSON(w, r, http.StatusOK, resp)
+}
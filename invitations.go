@@ -0,0 +1,338 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/model"
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/query"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gen"
+	"gorm.io/gorm"
+)
+
+// maxLookupResults GET /invitations 最多返回的记录数，每条都要查一次成员状态
+const maxLookupResults = 50
+
+// CreateInvitationRequest POST /invitations 的请求体，字段与表格的一行相同
+type CreateInvitationRequest struct {
+	OrderID any `json:"order_id"`
+	// OrderSource 默认为 bilibili
+	OrderSource    string `json:"order_source"`
+	GithubUsername string `json:"github_username"`
+	GithubEmail    string `json:"github_email"`
+	// ExpiresAt 可选，格式同表格中的到期时间列
+	ExpiresAt string `json:"expires_at"`
+}
+
+// InvitationResponse 邀请结果，以及对应的邀请记录（已是成员等情况下可能为空）
+type InvitationResponse struct {
+	Outcome    Outcome                `json:"outcome"`
+	Invitation *model.InvitationModel `json:"invitation"`
+}
+
+// createInvitation 不经过表格，直接邀请一位买家，走与表格行相同的流程
+func createInvitation(w http.ResponseWriter, r *http.Request) {
+	var (
+		err        error
+		statusCode = http.StatusOK
+		req        CreateInvitationRequest
+	)
+	defer func() {
+		if err != nil {
+			writeError(w, r, statusCode, err)
+		}
+	}()
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	if err = decoder.Decode(&req); err != nil {
+		statusCode = http.StatusBadRequest
+		err = fmt.Errorf("bind request error, err=%w", err)
+		return
+	}
+	content, err := req.content()
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		return
+	}
+
+	outcome := inviteOne(r.Context(), content)
+	invitation, err := latestInvitation(r.Context(), content)
+	if err != nil {
+		statusCode = http.StatusInternalServerError
+		return
+	}
+	writeJSON(w, r, http.StatusOK, InvitationResponse{Outcome: outcome, Invitation: invitation})
+}
+
+// content 按表格行的规则校验请求
+func (req CreateInvitationRequest) content() (Range, error) {
+	switch req.OrderSource {
+	case "", OrderSourceBilibili, OrderSourceAfdian:
+	default:
+		return Range{}, fmt.Errorf("invalid params, unknown order_source=%s", req.OrderSource)
+	}
+	content, bad, ok := validateRow(rawRow{
+		OrderID:        req.OrderID,
+		GithubUsername: req.GithubUsername,
+		GithubEmail:    req.GithubEmail,
+		ExpiresAt:      req.ExpiresAt,
+		OrderSource:    req.OrderSource,
+	})
+	if !ok {
+		if bad != nil {
+			return Range{}, fmt.Errorf("invalid params, %s", bad.Reason)
+		}
+		return Range{}, errors.New("invalid params, empty invitation")
+	}
+	return content, nil
+}
+
+// latestInvitation 优先取同一订单的记录，没有时取该用户最近的记录（如续费、已邀请过）
+func latestInvitation(ctx context.Context, content Range) (*model.InvitationModel, error) {
+	t := query.InvitationModel
+	invitation, err := t.WithContext(ctx).
+		Where(
+			t.OrderSource.Eq(content.orderSource()),
+			t.OrderID.Eq(content.OrderID),
+			t.GithubUsername.Eq(content.GithubUsername),
+		).
+		Order(t.UpdatedAt.Desc()).
+		First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		invitation, err = t.WithContext(ctx).
+			Where(t.GithubUsername.Eq(content.GithubUsername)).
+			Order(t.UpdatedAt.Desc()).
+			First()
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find_invitation_error||err=%v", err)
+	}
+	return invitation, nil
+}
+
+// InvitationDetail 邀请记录的当前状态、每次执行的结果和 GitHub 成员状态
+type InvitationDetail struct {
+	Invitation *model.InvitationModel          `json:"invitation"`
+	Attempts   []*model.InvitationAttemptModel `json:"attempts"`
+	FirstError string                          `json:"first_error"`
+	LastError  string                          `json:"last_error"`
+	Membership Membership                      `json:"membership"`
+}
+
+// Membership 查询时 GitHub 返回的成员状态，查询失败时 IsMember 为空
+type Membership struct {
+	IsMember *bool  `json:"is_member"`
+	Error    string `json:"error,omitempty"`
+}
+
+// getInvitation GET /invitations/{id}
+func getInvitation(w http.ResponseWriter, r *http.Request) {
+	var (
+		err        error
+		statusCode = http.StatusOK
+		id         = r.PathValue("id")
+	)
+	defer func() {
+		if err != nil {
+			writeError(w, r, statusCode, err)
+		}
+	}()
+
+	invitation, statusCode, err := findInvitation(r.Context(), id)
+	if err != nil {
+		return
+	}
+	details, err := invitationDetails(r.Context(), []*model.InvitationModel{invitation})
+	if err != nil {
+		statusCode = http.StatusInternalServerError
+		return
+	}
+	writeJSON(w, r, http.StatusOK, details[0])
+}
+
+// revocableStatuses 可以撤销的状态：已邀请或运营已手动处理
+var revocableStatuses = []string{InvitationStatusSucceeded, InvitationStatusResolved}
+
+var ErrNotRevocable = errors.New("invitation is not revocable")
+
+// revokeInvitation 移出组织（同时撤回未接受的邀请），再把记录标记为 REVOKED 并回写数据源
+func revokeInvitation(ctx context.Context, invitation *model.InvitationModel) error {
+	if !slices.Contains(revocableStatuses, invitation.InvitationStatus) {
+		return fmt.Errorf("status %s||err=%w", invitation.InvitationStatus, ErrNotRevocable)
+	}
+	if err := RemoveMember(ctx, invitation.GithubUsername, invitation.GithubEmail); err != nil {
+		return fmt.Errorf("remove_member_error||err=%w", err)
+	}
+	if err := setInvitationStatus(ctx, invitation, InvitationStatusRevoked); err != nil {
+		return err
+	}
+	logrus.WithFields(logrus.Fields{
+		"invitationID": invitation.ID,
+		"githubName":   invitation.GithubUsername,
+	}).Info("invitation_revoked")
+	return nil
+}
+
+// revokeInvitationHandler POST /invitations/{id}/revoke，返回撤销后的记录
+func revokeInvitationHandler(w http.ResponseWriter, r *http.Request) {
+	var (
+		err        error
+		statusCode = http.StatusOK
+		id         = r.PathValue("id")
+	)
+	defer func() {
+		if err != nil {
+			writeError(w, r, statusCode, err)
+		}
+	}()
+
+	invitation, statusCode, err := findInvitation(r.Context(), id)
+	if err != nil {
+		return
+	}
+	if err = revokeInvitation(r.Context(), invitation); err != nil {
+		statusCode = http.StatusBadGateway
+		if errors.Is(err, ErrNotRevocable) || errors.Is(err, ErrNothingToRemove) {
+			statusCode = http.StatusConflict
+		}
+		return
+	}
+	writeJSON(w, r, http.StatusOK, invitation)
+}
+
+// findInvitation 按 ID 取一条邀请记录，出错时同时返回对应的状态码
+func findInvitation(ctx context.Context, id string) (*model.InvitationModel, int, error) {
+	if err := uuid.Validate(id); err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid params, id=%s", id)
+	}
+	t := query.InvitationModel
+	invitation, err := t.WithContext(ctx).Where(t.ID.Eq(id)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, http.StatusNotFound, fmt.Errorf("invitation %s not found", id)
+	}
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("find_invitation_error||err=%v", err)
+	}
+	return invitation, http.StatusOK, nil
+}
+
+// findInvitations GET /invitations?order_id=&order_source=&username=&email=，条件为精确匹配，至少一个
+func findInvitations(w http.ResponseWriter, r *http.Request) {
+	var (
+		err        error
+		statusCode = http.StatusOK
+		q          = r.URL.Query()
+		t          = query.InvitationModel
+		conds      []gen.Condition
+	)
+	defer func() {
+		if err != nil {
+			writeError(w, r, statusCode, err)
+		}
+	}()
+
+	if v := strings.TrimSpace(q.Get("order_id")); v != "" {
+		orderID, err2 := strconv.ParseInt(v, 10, 64)
+		if err2 != nil {
+			statusCode = http.StatusBadRequest
+			err = fmt.Errorf("invalid params, order_id=%s", v)
+			return
+		}
+		conds = append(conds, t.OrderID.Eq(orderID))
+	}
+	if v := strings.TrimSpace(q.Get("username")); v != "" {
+		conds = append(conds, t.GithubUsername.Eq(v))
+	}
+	if v := strings.TrimSpace(q.Get("email")); v != "" {
+		conds = append(conds, t.GithubEmail.Lower().Eq(strings.ToLower(v)))
+	}
+	if len(conds) == 0 {
+		statusCode = http.StatusBadRequest
+		err = errors.New("invalid params, one of order_id, username or email is required")
+		return
+	}
+	if v := q.Get("order_source"); v != "" {
+		conds = append(conds, t.OrderSource.Eq(v))
+	}
+
+	do := t.WithContext(r.Context()).Where(conds...)
+	total, err := do.Count()
+	if err != nil {
+		statusCode = http.StatusInternalServerError
+		err = fmt.Errorf("count_invitation_error||err=%v", err)
+		return
+	}
+	invitations, err := do.Order(t.UpdatedAt.Desc(), t.ID.Desc()).Limit(maxLookupResults).Find()
+	if err != nil {
+		statusCode = http.StatusInternalServerError
+		err = fmt.Errorf("find_invitation_error||err=%v", err)
+		return
+	}
+	details, err := invitationDetails(r.Context(), invitations)
+	if err != nil {
+		statusCode = http.StatusInternalServerError
+		return
+	}
+	if details == nil {
+		details = []*InvitationDetail{}
+	}
+	writeJSON(w, r, http.StatusOK, ListResponse[*InvitationDetail]{Total: total, Items: details})
+}
+
+// invitationDetails 批量取执行历史，同一用户只查一次成员状态
+func invitationDetails(ctx context.Context, invitations []*model.InvitationModel) ([]*InvitationDetail, error) {
+	if len(invitations) == 0 {
+		return nil, nil
+	}
+	ids := make([]string, 0, len(invitations))
+	for _, invitation := range invitations {
+		ids = append(ids, invitation.ID)
+	}
+	a := query.InvitationAttemptModel
+	attempts, err := a.WithContext(ctx).Where(a.InvitationID.In(ids...)).Order(a.CreatedAt, a.ID).Find()
+	if err != nil {
+		return nil, fmt.Errorf("find_attempts_error||err=%v", err)
+	}
+	byInvitation := make(map[string][]*model.InvitationAttemptModel)
+	for _, attempt := range attempts {
+		byInvitation[attempt.InvitationID] = append(byInvitation[attempt.InvitationID], attempt)
+	}
+
+	memberships := make(map[string]Membership)
+	details := make([]*InvitationDetail, 0, len(invitations))
+	for _, invitation := range invitations {
+		membership, ok := memberships[invitation.GithubUsername]
+		if !ok {
+			if isMember, err := CheckIfUserIsMember(ctx, invitation.GithubUsername); err != nil {
+				membership.Error = err.Error()
+			} else {
+				membership.IsMember = &isMember
+			}
+			memberships[invitation.GithubUsername] = membership
+		}
+		detail := &InvitationDetail{
+			Invitation: invitation,
+			Attempts:   byInvitation[invitation.ID],
+			FirstError: invitation.FirstError,
+			LastError:  invitation.LastError,
+			Membership: membership,
+		}
+		if detail.Attempts == nil {
+			detail.Attempts = []*model.InvitationAttemptModel{}
+		}
+		details = append(details, detail)
+	}
+	return details, nil
+}
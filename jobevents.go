@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/model"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// SSE 事件名：start 为连接时的任务状态，row 为处理完的一行，finish 为结束时的任务状态
+	JobEventStart  = "start"
+	JobEventRow    = "row"
+	JobEventFinish = "finish"
+
+	// jobEventBuffer 订阅方缓冲的事件数，跟不上时断开
+	jobEventBuffer = 256
+	// jobEventPoll 保活间隔，同时从数据库补齐其他进程（如 invite 命令）写入的行和状态
+	jobEventPoll = 5 * time.Second
+)
+
+type jobEvent struct {
+	name string
+	row  *model.BatchRowModel
+	job  JobStatus
+}
+
+// jobEventHub 本进程内运行的任务的事件，按任务 ID 分发给订阅方
+type jobEventHub struct {
+	mu   sync.Mutex
+	subs map[string]map[chan jobEvent]struct{}
+}
+
+var jobEvents = &jobEventHub{subs: make(map[string]map[chan jobEvent]struct{})}
+
+func (h *jobEventHub) subscribe(jobID string) (<-chan jobEvent, func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ch := make(chan jobEvent, jobEventBuffer)
+	if h.subs[jobID] == nil {
+		h.subs[jobID] = make(map[chan jobEvent]struct{})
+	}
+	h.subs[jobID][ch] = struct{}{}
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.remove(jobID, ch)
+	}
+}
+
+// remove 调用方需持有锁；已被移除的订阅不会重复关闭
+func (h *jobEventHub) remove(jobID string, ch chan jobEvent) {
+	if _, ok := h.subs[jobID][ch]; !ok {
+		return
+	}
+	delete(h.subs[jobID], ch)
+	close(ch)
+	if len(h.subs[jobID]) == 0 {
+		delete(h.subs, jobID)
+	}
+}
+
+// publish 不阻塞运行，缓冲已满的订阅方直接断开，客户端重连后会从数据库回放
+func (h *jobEventHub) publish(jobID string, ev jobEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs[jobID] {
+		select {
+		case ch <- ev:
+		default:
+			logrus.WithField("jobID", jobID).Warn("job_events_subscriber_dropped")
+			h.remove(jobID, ch)
+		}
+	}
+}
+
+// publishJobFinish 批次状态写回后调用，以数据库中的最终状态为准
+func publishJobFinish(ctx context.Context, jobID string) {
+	batch, _, err := findBatch(ctx, jobID)
+	if err != nil {
+		logrus.WithField("jobID", jobID).WithError(err).Error("job_events_finish_error")
+		return
+	}
+	jobEvents.publish(jobID, jobEvent{name: JobEventFinish, job: newJobStatus(batch)})
+}
+
+// jobEventStream GET /jobs/{id}/events，Server-Sent Events：
+// 先发送 start 和已处理的行（有 cursor 时只回放 cursor 之后的行），之后每处理一行发送一个 row，结束时发送 finish 并关闭连接
+func jobEventStream(w http.ResponseWriter, r *http.Request) {
+	var (
+		err        error
+		statusCode = http.StatusOK
+		id         = r.PathValue("id")
+	)
+	defer func() {
+		if err != nil {
+			writeError(w, r, statusCode, err)
+		}
+	}()
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		statusCode = http.StatusInternalServerError
+		err = errors.New("streaming unsupported")
+		return
+	}
+	stream := &sseStream{w: w, flusher: flusher, seen: make(map[string]bool)}
+	if v := r.URL.Query().Get("cursor"); v != "" {
+		if stream.cursor, err = decodeListCursor(v); err != nil {
+			statusCode = http.StatusBadRequest
+			return
+		}
+	}
+	// 先订阅再读数据库，两者之间处理的行会同时出现在回放和事件中，按行 ID 去重
+	events, unsubscribe := jobEvents.subscribe(id)
+	defer unsubscribe()
+	batch, statusCode, err := findBatch(r.Context(), id)
+	if err != nil {
+		return
+	}
+	rows, err := stream.unsent(r.Context(), id)
+	if err != nil {
+		statusCode = http.StatusInternalServerError
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	stream.send(JobEventStart, newJobStatus(batch))
+	stream.rows(rows)
+	if batch.Status != BatchStatusRunning {
+		stream.send(JobEventFinish, newJobStatus(batch))
+		return
+	}
+
+	ticker := time.NewTicker(jobEventPoll)
+	defer ticker.Stop()
+	for stream.err == nil {
+		select {
+		case <-r.Context().Done():
+			return
+		case ev, ok := <-events:
+			if !ok {
+				return
+			}
+			if ev.name == JobEventFinish {
+				stream.send(JobEventFinish, ev.job)
+				return
+			}
+			stream.rows([]*model.BatchRowModel{ev.row})
+		case <-ticker.C:
+			// 先查状态再补行，结束前写入的行都能补上
+			batch, _, err2 := findBatch(r.Context(), id)
+			if rows, err3 := stream.unsent(r.Context(), id); err3 == nil {
+				stream.rows(rows)
+			}
+			if err2 == nil && batch.Status != BatchStatusRunning {
+				stream.send(JobEventFinish, newJobStatus(batch))
+				return
+			}
+			stream.comment("keepalive")
+		}
+	}
+	logrus.WithField("jobID", id).WithError(stream.err).Debug("job_events_closed")
+}
+
+// sseStream 写出事件，写失败后不再继续
+type sseStream struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+	seen    map[string]bool
+	err     error
+	// cursor 已从数据库读到的最后一行，之后只读新写入的行
+	cursor *listCursor
+}
+
+// unsent 从数据库读出 cursor 之后写入的行，逐页读完
+func (s *sseStream) unsent(ctx context.Context, id string) ([]*model.BatchRowModel, error) {
+	var rows []*model.BatchRowModel
+	for {
+		page, err := batchRowsPage(ctx, id, ListParams{Limit: maxPageSize, Cursor: s.cursor})
+		if err != nil {
+			return rows, err
+		}
+		if n := len(page.Items); n > 0 {
+			last := page.Items[n-1]
+			s.cursor = &listCursor{At: last.CreatedAt, ID: last.ID}
+			rows = append(rows, page.Items...)
+		}
+		if page.NextCursor == "" {
+			return rows, nil
+		}
+	}
+}
+
+func (s *sseStream) rows(rows []*model.BatchRowModel) {
+	for _, row := range rows {
+		if s.seen[row.ID] {
+			continue
+		}
+		s.seen[row.ID] = true
+		s.send(JobEventRow, row)
+	}
+}
+
+func (s *sseStream) send(name string, data any) {
+	if s.err != nil {
+		return
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		s.err = err
+		return
+	}
+	if _, s.err = fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", name, b); s.err == nil {
+		s.flusher.Flush()
+	}
+}
+
+func (s *sseStream) comment(text string) {
+	if s.err != nil {
+		return
+	}
+	if _, s.err = fmt.Fprintf(s.w, ": %s\n\n", text); s.err == nil {
+		s.flusher.Flush()
+	}
+}
@@ -0,0 +1,283 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/model"
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/query"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+// JobCreated POST /invite 的响应，任务 ID 即批次 ID
+type JobCreated struct {
+	JobID  string `json:"job_id"`
+	Status string `json:"status"`
+}
+
+// JobProgress 已处理的行数及各结果的计数，试运行时为计划的数量，每一行的动作见 rows 中的 action
+type JobProgress struct {
+	Done      int32 `json:"done"`
+	Succeeded int32 `json:"succeeded"`
+	Renewed   int32 `json:"renewed"`
+	Skipped   int32 `json:"skipped"`
+	Failed    int32 `json:"failed"`
+	Invalid   int32 `json:"invalid"`
+}
+
+// JobStatus 任务的状态和计数，不含每一行的结果
+type JobStatus struct {
+	ID         string      `json:"id"`
+	Source     string      `json:"source"`
+	Detail     string      `json:"detail"`
+	Status     string      `json:"status"`
+	DryRun     bool        `json:"dry_run"`
+	Error      string      `json:"error,omitempty"`
+	Progress   JobProgress `json:"progress"`
+	CreatedAt  time.Time   `json:"created_at"`
+	FinishedAt *time.Time  `json:"finished_at,omitempty"`
+}
+
+// JobResponse GET /jobs/{id} 的响应
+type JobResponse struct {
+	JobStatus
+	Rows []*model.BatchRowModel `json:"rows"`
+	// NextCursor 还有更多行时返回，作为 cursor 参数取下一页
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+func newJobStatus(batch *model.BatchModel) JobStatus {
+	status := JobStatus{
+		ID:     batch.ID,
+		Source: batch.Source,
+		Detail: batch.Detail,
+		Status: batch.Status,
+		DryRun: batch.DryRun,
+		Error:  batch.Error,
+		Progress: JobProgress{
+			Done:      batch.SucceededCnt + batch.RenewedCnt + batch.SkippedCnt + batch.FailedCnt + batch.InvalidCnt,
+			Succeeded: batch.SucceededCnt,
+			Renewed:   batch.RenewedCnt,
+			Skipped:   batch.SkippedCnt,
+			Failed:    batch.FailedCnt,
+			Invalid:   batch.InvalidCnt,
+		},
+		CreatedAt: batch.CreatedAt,
+	}
+	if !isUnset(batch.FinishedAt) {
+		status.FinishedAt = &batch.FinishedAt
+	}
+	return status
+}
+
+func newJobResponse(batch *model.BatchModel, rows []*model.BatchRowModel) *JobResponse {
+	resp := &JobResponse{JobStatus: newJobStatus(batch), Rows: rows}
+	if resp.Rows == nil {
+		resp.Rows = []*model.BatchRowModel{}
+	}
+	return resp
+}
+
+// getJob 任务进度和每一行的结果，行按 limit、cursor 分页；/imports 返回的 batch_id 同样可以查询
+func getJob(w http.ResponseWriter, r *http.Request) {
+	var (
+		err        error
+		statusCode = http.StatusOK
+		id         = r.PathValue("id")
+	)
+	defer func() {
+		if err != nil {
+			writeError(w, r, statusCode, err)
+		}
+	}()
+
+	params, err := parsePageParams(r.URL.Query())
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		return
+	}
+	batch, statusCode, err := findBatch(r.Context(), id)
+	if err != nil {
+		return
+	}
+	page, err := batchRowsPage(r.Context(), id, params)
+	if err != nil {
+		statusCode = http.StatusInternalServerError
+		return
+	}
+	resp := newJobResponse(batch, page.Items)
+	resp.NextCursor = page.NextCursor
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// findBatch 按任务 ID 取批次，出错时同时返回对应的状态码
+func findBatch(ctx context.Context, id string) (*model.BatchModel, int, error) {
+	if err := uuid.Validate(id); err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid params, id=%s", id)
+	}
+	t := query.BatchModel
+	batch, err := t.WithContext(ctx).Where(t.ID.Eq(id)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, http.StatusNotFound, fmt.Errorf("job %s not found", id)
+	}
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("find_batch_error||err=%v", err)
+	}
+	return batch, http.StatusOK, nil
+}
+
+func batchRows(ctx context.Context, id string) ([]*model.BatchRowModel, error) {
+	b := query.BatchRowModel
+	rows, err := b.WithContext(ctx).Where(b.BatchID.Eq(id)).Order(b.CreatedAt, b.RowNumber).Find()
+	if err != nil {
+		return nil, fmt.Errorf("find_batch_rows_error||err=%v", err)
+	}
+	return rows, nil
+}
+
+// batchRowsPage 按 (created_at, id) 升序分页取批次中的行，不统计总数，总数见任务进度
+func batchRowsPage(ctx context.Context, id string, p ListParams) (*ListResponse[*model.BatchRowModel], error) {
+	b := query.BatchRowModel
+	p.Asc = true
+	conds, order := listColumns{id: b.ID, at: b.CreatedAt}.page(p)
+	rows, err := b.WithContext(ctx).
+		Where(append(conds, b.BatchID.Eq(id))...).
+		Order(order...).
+		Limit(p.Limit + 1).
+		Find()
+	if err != nil {
+		return nil, fmt.Errorf("find_batch_rows_error||err=%v", err)
+	}
+	return paginate(p, 0, rows, func(m *model.BatchRowModel) listCursor {
+		return listCursor{At: m.CreatedAt, ID: m.ID}
+	}), nil
+}
+
+// runningBatch 同一数据源、同一范围正在运行的批次，试运行与正式运行分开判断，没有时返回 nil
+func runningBatch(ctx context.Context, src InvitationSource, dryRun bool) (*model.BatchModel, error) {
+	t := query.BatchModel
+	batch, err := t.WithContext(ctx).
+		Where(t.Source.Eq(src.Name()), t.Detail.Eq(src.Detail()), t.Status.Eq(BatchStatusRunning), t.DryRun.Is(dryRun)).
+		Order(t.CreatedAt.Desc()).
+		First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find_running_batch_error||err=%v", err)
+	}
+	return batch, nil
+}
+
+const (
+	// batchHeartbeat 运行中的批次每隔这么久更新一次 heartbeat_at
+	batchHeartbeat = 30 * time.Second
+	// batchStaleAfter heartbeat_at 超过这么久没有更新的 RUNNING 批次视为已中断
+	batchStaleAfter = 2 * time.Minute
+)
+
+// batchOwner 当前进程的标识，写入批次的 owner；服务和 invite 命令可能同时运行
+var batchOwner = func() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s/%d/%s", host, os.Getpid(), uuid.New().String()[:8])
+}()
+
+// heartbeat 定期更新批次的 heartbeat_at，返回的函数用于停止
+func (run *inviteRun) heartbeat(ctx context.Context) (stop func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(batchHeartbeat)
+		defer ticker.Stop()
+		t := query.BatchModel
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := t.WithContext(ctx).
+					Where(t.ID.Eq(run.batchID), t.Status.Eq(BatchStatusRunning)).
+					UpdateColumnSimple(t.HeartbeatAt.Value(time.Now())); err != nil && ctx.Err() == nil {
+					logrus.WithError(err).WithField("batchID", run.batchID).Error("_db_batch_heartbeat_error")
+				}
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// failStaleBatches heartbeat_at 长时间没有更新的 RUNNING 批次所在的进程已经退出，标记为失败，
+// 同时释放 batches_running_uidx；其他进程仍在运行的批次不受影响
+func failStaleBatches(ctx context.Context) {
+	t := query.BatchModel
+	info, err := t.WithContext(ctx).
+		Where(t.Status.Eq(BatchStatusRunning), t.HeartbeatAt.Lt(time.Now().Add(-batchStaleAfter))).
+		UpdateColumnSimple(
+			t.Status.Value(BatchStatusFailed),
+			t.Error.Value("interrupted: owner stopped sending heartbeats"),
+			t.FinishedAt.Value(time.Now()),
+		)
+	if err != nil {
+		logrus.WithError(err).Error("_db_fail_stale_batches_error")
+		return
+	}
+	if info.RowsAffected > 0 {
+		logrus.Warnf("marked %d interrupted batches as failed", info.RowsAffected)
+	}
+}
+
+// runInviteCommand 在命令行中运行一次邀请并打印每一行的结果：
+//
+//	invite [-source sheet|bitable] [-start A2] [-end C] [-dry-run]
+func runInviteCommand(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("invite", flag.ContinueOnError)
+	source := fs.String("source", SourceSheet, "sheet or bitable")
+	start := fs.String("start", viper.GetString("feishu.range.start"), "sheet range start")
+	end := fs.String("end", viper.GetString("feishu.range.end"), "sheet range end")
+	dryRun := fs.Bool("dry-run", false, "only report the planned action per row")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	src, err := newInviteSource(*source, *start, *end)
+	if err != nil {
+		return err
+	}
+	run, err := startBatch(ctx, src, *dryRun)
+	if err != nil {
+		return err
+	}
+	runErr := run.execute(ctx, src)
+
+	rows, err := batchRows(ctx, run.batchID)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ROW\tORDER\tUSERNAME\tRESULT\tREASON")
+	for _, row := range rows {
+		result := row.Result
+		if row.Action != "" {
+			result = row.Action
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", row.RowNumber, row.OrderID, row.GithubUsername, result, row.Reason)
+	}
+	if err = w.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("\njob %s: succeeded=%d renewed=%d skipped=%d failed=%d invalid=%d dry_run=%t\n",
+		run.batchID, len(run.successList), len(run.renewed), len(run.skipped), len(run.failedList), len(run.invalid), *dryRun)
+	return runErr
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Nicknamezz00/org-invitation-autobot/store"
+	"github.com/google/uuid"
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// ListParams /success、/failed 的查询参数：
+// from、to 为时间范围（RFC3339 或 2006-01-02，to 只有日期时包含当天），
+// username、email、order（订单号）为子串匹配，sort 为 asc 或 desc（默认），limit 为每页条数，cursor 为上一页返回的 next_cursor
+type ListParams struct {
+	From     time.Time
+	To       time.Time
+	Username string
+	Email    string
+	OrderID  string
+	Asc      bool
+	Limit    int
+	Cursor   *listCursor
+}
+
+// ListResponse 分页结果，Total 为满足筛选条件的总数，与游标无关
+type ListResponse[T any] struct {
+	Total      int64  `json:"total"`
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// listCursor 按 (时间, id) 做 keyset 分页，同一条邀请可能在同一时刻有多行，id 用来打破并列
+type listCursor struct {
+	At time.Time
+	ID string
+}
+
+func (c listCursor) encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(c.At.Format(time.RFC3339Nano) + "|" + c.ID))
+}
+
+func decodeListCursor(s string) (*listCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	at, id, ok := strings.Cut(string(b), "|")
+	if !ok {
+		return nil, errors.New("invalid cursor")
+	}
+	t, err := time.Parse(time.RFC3339Nano, at)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	// 分页的表 id 都是 uuid，不校验时格式错误的 id 会在查询时报错，返回 500
+	if uuid.Validate(id) != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	return &listCursor{At: t, ID: id}, nil
+}
+
+func parseListParams(q url.Values) (p ListParams, err error) {
+	if p.From, err = parseListTime(q.Get("from"), false); err != nil {
+		return p, fmt.Errorf("invalid from: %w", err)
+	}
+	if p.To, err = parseListTime(q.Get("to"), true); err != nil {
+		return p, fmt.Errorf("invalid to: %w", err)
+	}
+	p.Username = strings.TrimSpace(q.Get("username"))
+	p.Email = strings.TrimSpace(q.Get("email"))
+	p.OrderID = strings.TrimSpace(q.Get("order"))
+	switch q.Get("sort") {
+	case "", "desc":
+	case "asc":
+		p.Asc = true
+	default:
+		return p, fmt.Errorf("invalid sort %q", q.Get("sort"))
+	}
+	page, err := parsePageParams(q)
+	if err != nil {
+		return p, err
+	}
+	p.Limit, p.Cursor = page.Limit, page.Cursor
+	return p, nil
+}
+
+// parsePageParams 只解析 limit、cursor，用于没有筛选条件的分页
+func parsePageParams(q url.Values) (p ListParams, err error) {
+	p.Limit = defaultPageSize
+	if v := q.Get("limit"); v != "" {
+		if p.Limit, err = strconv.Atoi(v); err != nil || p.Limit <= 0 || p.Limit > maxPageSize {
+			return p, fmt.Errorf("invalid limit %q, must be 1-%d", v, maxPageSize)
+		}
+	}
+	if v := q.Get("cursor"); v != "" {
+		if p.Cursor, err = decodeListCursor(v); err != nil {
+			return p, err
+		}
+	}
+	return p, nil
+}
+
+// parseListTime 只有日期时，end 为 true 取次日零点，使 to 包含当天
+func parseListTime(s string, end bool) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation(time.DateOnly, s, time.Local)
+	if err != nil {
+		return t, err
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// containsPattern 转义 LIKE 通配符后两端加 %
+func containsPattern(s string) string {
+	return "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s) + "%"
+}
+
+// listColumns 两张流水表共有的列，at 分别为 succeeded_at、failed_at
+type listColumns struct {
+	id       field.String
+	at       field.Time
+	username field.String
+	email    field.String
+}
+
+// orderIDContains order_id 为 BIGINT，CAST 成文本后做子串匹配；field 没有 CAST，用 gorm 的条件包成 gen 的分组条件
+func orderIDContains(s string) gen.Condition {
+	var do gen.DO
+	do.UseDB(store.PostgresDB.Where("CAST(order_id AS TEXT) LIKE ?", containsPattern(s)))
+	return &do
+}
+
+// filters 筛选条件，不含游标，用于统计总数
+func (c listColumns) filters(p ListParams) []gen.Condition {
+	var conds []gen.Condition
+	if !p.From.IsZero() {
+		conds = append(conds, c.at.Gte(p.From))
+	}
+	if !p.To.IsZero() {
+		conds = append(conds, c.at.Lt(p.To))
+	}
+	if p.Username != "" {
+		conds = append(conds, c.username.Lower().Like(containsPattern(strings.ToLower(p.Username))))
+	}
+	if p.Email != "" {
+		conds = append(conds, c.email.Lower().Like(containsPattern(strings.ToLower(p.Email))))
+	}
+	if p.OrderID != "" {
+		conds = append(conds, orderIDContains(p.OrderID))
+	}
+	return conds
+}
+
+// page 筛选条件加上游标条件，以及对应的排序
+func (c listColumns) page(p ListParams) ([]gen.Condition, []field.Expr) {
+	conds := c.filters(p)
+	if p.Cursor != nil {
+		if p.Asc {
+			conds = append(conds, field.Or(c.at.Gt(p.Cursor.At), field.And(c.at.Eq(p.Cursor.At), c.id.Gt(p.Cursor.ID))))
+		} else {
+			conds = append(conds, field.Or(c.at.Lt(p.Cursor.At), field.And(c.at.Eq(p.Cursor.At), c.id.Lt(p.Cursor.ID))))
+		}
+	}
+	if p.Asc {
+		return conds, []field.Expr{c.at, c.id}
+	}
+	return conds, []field.Expr{c.at.Desc(), c.id.Desc()}
+}
+
+// paginate 多取的一条用来判断是否还有下一页
+func paginate[T any](p ListParams, total int64, items []T, cursor func(T) listCursor) *ListResponse[T] {
+	r := &ListResponse[T]{Total: total, Items: items}
+	if len(items) > p.Limit {
+		r.Items = items[:p.Limit]
+		r.NextCursor = cursor(r.Items[p.Limit-1]).encode()
+	}
+	if r.Items == nil {
+		r.Items = []T{}
+	}
+	return r
+}
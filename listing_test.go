@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+func TestDecodeListCursor(t *testing.T) {
+	at := time.Date(2026, 3, 1, 12, 30, 0, 123456789, time.UTC)
+	id := "0b6f1b2c-5d1e-4a3b-9c8d-7e6f5a4b3c2d"
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+	tests := []struct {
+		name    string
+		s       string
+		wantErr bool
+	}{
+		{name: "round trip", s: listCursor{At: at, ID: id}.encode()},
+		{name: "not base64", s: "!!!", wantErr: true},
+		{name: "padded base64", s: base64.URLEncoding.EncodeToString([]byte("x")), wantErr: true},
+		{name: "missing separator", s: encode(at.Format(time.RFC3339Nano)), wantErr: true},
+		{name: "bad time", s: encode("yesterday|" + id), wantErr: true},
+		{name: "bad id", s: encode(at.Format(time.RFC3339Nano) + "|42"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeListCursor(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodeListCursor(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !got.At.Equal(at) || got.ID != id {
+				t.Errorf("decodeListCursor() = %+v, want {%v %s}", got, at, id)
+			}
+		})
+	}
+}
+
+func TestParseListTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		end     bool
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "empty", s: ""},
+		{name: "rfc3339", s: "2026-03-01T12:30:00+08:00", want: time.Date(2026, 3, 1, 4, 30, 0, 0, time.UTC)},
+		{name: "rfc3339 end", s: "2026-03-01T12:30:00Z", end: true, want: time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC)},
+		{name: "date from", s: "2026-03-01", want: time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)},
+		{name: "date to includes the day", s: "2026-03-01", end: true, want: time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)},
+		{name: "bad date", s: "2026-13-01", wantErr: true},
+		{name: "garbage", s: "today", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseListTime(tt.s, tt.end)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseListTime(%q, %v) error = %v, wantErr %v", tt.s, tt.end, err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("parseListTime(%q, %v) = %v, want %v", tt.s, tt.end, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainsPattern(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{s: "", want: "%%"},
+		{s: "octo", want: "%octo%"},
+		{s: "50%", want: `%50\%%`},
+		{s: "a_b", want: `%a\_b%`},
+		{s: `a\b`, want: `%a\\b%`},
+		{s: `\%`, want: `%\\\%%`},
+	}
+	for _, tt := range tests {
+		if got := containsPattern(tt.s); got != tt.want {
+			t.Errorf("containsPattern(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/Nicknamezz00/org-invitation-autobot/store"
 	"github.com/robfig/cron/v3"
 	"github.com/sirupsen/logrus"
+	"gorm.io/gen/field"
 	"gorm.io/gorm"
 
 	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/model"
@@ -27,10 +28,41 @@ const (
 	// SENSITIVE environment variables below:
 	EnvFeishuAppSecret           = "FEISHU_APP_SECRET"
 	EnvGithubPersonalAccessToken = "GITHUB_PERSONAL_ACCESS_TOKEN"
+	// 可选，未设置时不注册飞书卡片回调
+	EnvFeishuCardVerificationToken = "FEISHU_CARD_VERIFICATION_TOKEN"
+	EnvFeishuCardEncryptKey        = "FEISHU_CARD_ENCRYPT_KEY"
+	// bilibili.verifier 为 mall 时必须设置
+	EnvBilibiliMallCookie = "BILIBILI_MALL_COOKIE"
+	// 与 afdian.user_id 一起设置时启用爱发电 webhook
+	EnvAfdianAPIToken = "AFDIAN_API_TOKEN"
 
 	InvitationStatusPending   = "PENDING"
 	InvitationStatusSucceeded = "SUCCEEDED"
 	InvitationStatusFailed    = "FAILED"
+	// InvitationStatusInvalid 仅用于回写数据源，校验未通过的行不进入 invitations 表
+	InvitationStatusInvalid = "INVALID"
+	// 运营在失败通知卡片上的处理结果，两者都会让后续运行跳过该用户
+	InvitationStatusIgnored  = "IGNORED"
+	InvitationStatusResolved = "RESOLVED"
+	// 订单已用完名额仍出现新的 GitHub 账号
+	InvitationStatusSuspectedAbuse = "SUSPECTED_ABUSE"
+	// 会员到期后已移出组织
+	InvitationStatusExpired = "EXPIRED"
+	// 运营手动撤销，已移出组织或撤回邀请，后续运行跳过该用户
+	InvitationStatusRevoked = "REVOKED"
+	// InvitationStatusRenewed 仅用于回写数据源，续费行只延长原记录的到期时间
+	InvitationStatusRenewed = "RENEWED"
+	// InvitationStatusBlocked 仅用于回写数据源，命中封禁名单的行不产生邀请记录
+	InvitationStatusBlocked = "BLOCKED"
+	// InvitationStatusSkipped 仅用于回写数据源，已邀请、已忽略或订单已到期的行
+	InvitationStatusSkipped = "SKIPPED"
+
+	SourceSheet   = "sheet"
+	SourceBitable = "bitable"
+
+	// 订单来源，决定订单号的含义和核验方式
+	OrderSourceBilibili = "bilibili"
+	OrderSourceAfdian   = "afdian"
 )
 
 var (
@@ -42,7 +74,24 @@ var lazyInit = map[string]any{
 	EnvGithubPersonalAccessToken: &githubPersonalAccessToken,
 }
 
-func init() {
+var lazyInitOptional = map[string]any{
+	EnvFeishuCardVerificationToken: &feishuCardVerificationToken,
+	EnvFeishuCardEncryptKey:        &feishuCardEncryptKey,
+	EnvBilibiliMallCookie:          &bilibiliMallCookie,
+	EnvAfdianAPIToken:              &afdianAPIToken,
+}
+
+var ErrIgnored = errors.New("ignored by operator, skip")
+
+// 可空的时间列默认为 epoch 表示未设置；epoch 按会话时区写入，留出一天余量
+var epochFloor = time.Unix(0, 0).AddDate(0, 0, 1)
+
+func isUnset(t time.Time) bool {
+	return !t.After(epochFloor)
+}
+
+// setup 读取环境变量和配置文件，放在 main 里调用，测试时不需要这些环境
+func setup() {
 	if err := MustGetEnvs(); err != nil {
 		logrus.Fatalln(err)
 	}
@@ -63,23 +112,87 @@ func init() {
 }
 
 func main() {
+	setup()
 	db := store.New(viper.GetViper())
 	query.SetDefault(db)
+	if len(os.Args) > 1 && os.Args[1] == "apikey" {
+		if err := runAPIKeyCommand(context.Background(), os.Args[2:]); err != nil {
+			logrus.Fatalln(err)
+		}
+		return
+	}
+	setupNotifier()
+	if err := setupPurchaseVerifier(); err != nil {
+		logrus.Fatalln(err)
+	}
+	if err := setupAfdian(); err != nil {
+		logrus.Fatalln(err)
+	}
+	if len(os.Args) > 1 && os.Args[1] == "invite" {
+		if err := runInviteCommand(context.Background(), os.Args[2:]); err != nil {
+			logrus.Fatalln(err)
+		}
+		return
+	}
+	failStaleBatches(context.Background())
 
 	c := cron.New()
-	c.AddFunc("0 9 * * *", func() { callInviteEndpoint() })
-	c.AddFunc("0 21 * * *", func() { callInviteEndpoint() })
+	sheetRange := sheetCronPayload()
+	c.AddFunc("0 9 * * *", func() { callInviteEndpoint(sheetRange) })
+	c.AddFunc("0 21 * * *", func() { callInviteEndpoint(sheetRange) })
+	if conf, err := bitableConfig(); err == nil && conf.Enabled() {
+		c.AddFunc("30 9 * * *", func() { callInviteEndpoint(`{"source":"bitable"}`) })
+		c.AddFunc("30 21 * * *", func() { callInviteEndpoint(`{"source":"bitable"}`) })
+	}
+	c.AddFunc("0 10 * * *", func() { runExpiryJob(context.Background()) })
+	c.AddFunc("@every 1m", func() { failStaleBatches(context.Background()) })
 	c.Start()
 	defer c.Stop()
 
 	mux := http.NewServeMux()
-	mux.HandleFunc("/invite", invite)
-	mux.HandleFunc("/success", success)
-	mux.HandleFunc("/failed", failed)
+	// 存活、就绪探针，供 docker-compose 和负载均衡使用；不需要认证的路由见 publicPaths
+	ready := newReadiness(db)
+	mux.HandleFunc("GET /healthz", healthz)
+	mux.HandleFunc("GET /readyz", ready.readyz)
+	// 每项检查的详细结果包含上游的错误内容，需要认证
+	mux.HandleFunc("GET /health", requireRole(RoleViewer, ready.health))
+	mux.HandleFunc("GET /api/v1/health", v1(requireRole(RoleViewer, ready.health)))
+	registerDBMetrics(db)
+	mux.HandleFunc("GET /metrics", requireRole(RoleViewer, serveMetrics))
+	mux.HandleFunc("/invite", requireRole(RoleOperator, invite))
+	mux.HandleFunc("/success", requireRole(RoleViewer, success))
+	mux.HandleFunc("/failed", requireRole(RoleViewer, failed))
+	mux.HandleFunc("/imports", requireRole(RoleOperator, imports))
+	mux.HandleFunc("/reports/abuse", requireRole(RoleViewer, abuseReport))
+	mux.HandleFunc("POST /invitations", requireRole(RoleOperator, createInvitation))
+	mux.HandleFunc("POST /invitations/retry", requireRole(RoleOperator, retryFailed))
+	mux.HandleFunc("GET /invitations", requireRole(RoleViewer, findInvitations))
+	mux.HandleFunc("GET /invitations/{id}", requireRole(RoleViewer, getInvitation))
+	mux.HandleFunc("POST /invitations/{id}/revoke", requireRole(RoleOperator, revokeInvitationHandler))
+	mux.HandleFunc("GET /jobs/{id}", requireRole(RoleViewer, getJob))
+	mux.HandleFunc("GET /jobs/{id}/events", requireRole(RoleViewer, jobEventStream))
+	mux.HandleFunc("GET /exports/invitations", requireRole(RoleViewer, exportInvitations))
+	mux.HandleFunc("GET /access-rules", requireRole(RoleOperator, listAccessRules))
+	mux.HandleFunc("POST /access-rules", requireRole(RoleAdmin, createAccessRule))
+	mux.HandleFunc("PATCH /access-rules/{id}", requireRole(RoleAdmin, updateAccessRule))
+	mux.HandleFunc("DELETE /access-rules/{id}", requireRole(RoleAdmin, deleteAccessRule))
+	registerAPIv1(mux)
+	registerDashboard(mux)
+	if handler := cardActionHandler(); handler != nil {
+		mux.HandleFunc("/feishu/card", handler)
+	}
+	if handler := afdianWebhookHandler(); handler != nil {
+		mux.HandleFunc("/webhooks/afdian", handler)
+	}
+	// 买家自助领取页面，核验订单并限流，不需要 API key
+	if portal := newClaimPortal(); portal != nil {
+		mux.HandleFunc("GET /claim", portal.page)
+		mux.HandleFunc("POST /claim", portal.claim)
+	}
 
 	server := &http.Server{
 		Addr:    ":8182",
-		Handler: mux,
+		Handler: authMiddleware(mux),
 	}
 	go func() {
 		logrus.Println("HTTP Server listening :8182")
@@ -106,6 +219,7 @@ func main() {
 	}
 }
 
+// success 邀请成功流水，查询参数见 ListParams
 func success(w http.ResponseWriter, r *http.Request) {
 	var (
 		err        error
@@ -113,7 +227,7 @@ func success(w http.ResponseWriter, r *http.Request) {
 	)
 	defer func() {
 		if err != nil {
-			http.Error(w, err.Error(), statusCode)
+			writeError(w, r, statusCode, err)
 		}
 	}()
 	if r.Method != http.MethodGet {
@@ -121,18 +235,31 @@ func success(w http.ResponseWriter, r *http.Request) {
 		statusCode = http.StatusMethodNotAllowed
 		return
 	}
-	all, err := query.SuccessfulInvitationModel.WithContext(r.Context()).Find()
+	params, err := parseListParams(r.URL.Query())
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		return
+	}
+	t := query.SuccessfulInvitationModel
+	cols := listColumns{id: t.ID, at: t.SucceededAt, username: t.GithubUsername, email: t.GithubEmail}
+	total, err := t.WithContext(r.Context()).Where(cols.filters(params)...).Count()
 	if err != nil {
 		statusCode = http.StatusInternalServerError
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	if err = json.NewEncoder(w).Encode(all); err != nil {
+	conds, order := cols.page(params)
+	items, err := t.WithContext(r.Context()).Where(conds...).Order(order...).Limit(params.Limit + 1).Find()
+	if err != nil {
 		statusCode = http.StatusInternalServerError
 		return
 	}
+	resp := paginate(params, total, items, func(m *model.SuccessfulInvitationModel) listCursor {
+		return listCursor{At: m.SucceededAt, ID: m.ID}
+	})
+	writeJSON(w, r, http.StatusOK, resp)
 }
 
+// failed 邀请失败流水，查询参数见 ListParams
 func failed(w http.ResponseWriter, r *http.Request) {
 	var (
 		err        error
@@ -140,7 +267,7 @@ func failed(w http.ResponseWriter, r *http.Request) {
 	)
 	defer func() {
 		if err != nil {
-			http.Error(w, err.Error(), statusCode)
+			writeError(w, r, statusCode, err)
 		}
 	}()
 	if r.Method != http.MethodGet {
@@ -148,16 +275,28 @@ func failed(w http.ResponseWriter, r *http.Request) {
 		statusCode = http.StatusMethodNotAllowed
 		return
 	}
-	all, err := query.FailedInvitationModel.WithContext(r.Context()).Find()
+	params, err := parseListParams(r.URL.Query())
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		return
+	}
+	t := query.FailedInvitationModel
+	cols := listColumns{id: t.ID, at: t.FailedAt, username: t.GithubUsername, email: t.GithubEmail}
+	total, err := t.WithContext(r.Context()).Where(cols.filters(params)...).Count()
 	if err != nil {
 		statusCode = http.StatusInternalServerError
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	if err = json.NewEncoder(w).Encode(all); err != nil {
+	conds, order := cols.page(params)
+	items, err := t.WithContext(r.Context()).Where(conds...).Order(order...).Limit(params.Limit + 1).Find()
+	if err != nil {
 		statusCode = http.StatusInternalServerError
 		return
 	}
+	resp := paginate(params, total, items, func(m *model.FailedInvitationModel) listCursor {
+		return listCursor{At: m.FailedAt, ID: m.ID}
+	})
+	writeJSON(w, r, http.StatusOK, resp)
 }
 
 func invite(w http.ResponseWriter, r *http.Request) {
@@ -165,8 +304,11 @@ func invite(w http.ResponseWriter, r *http.Request) {
 		err        error
 		statusCode = http.StatusOK
 		rng        struct {
-			Start string `json:"start"`
-			End   string `json:"end"`
+			Source string `json:"source"`
+			Start  string `json:"start"`
+			End    string `json:"end"`
+			// DryRun 只读取和核验，返回的任务中记录每一行计划的动作
+			DryRun bool `json:"dry_run"`
 		}
 	)
 	defer func() {
@@ -174,7 +316,7 @@ func invite(w http.ResponseWriter, r *http.Request) {
 		//	http.Error(w, fmt.Sprintf("%v", r), http.StatusInternalServerError)
 		//}
 		if err != nil {
-			http.Error(w, err.Error(), statusCode)
+			writeError(w, r, statusCode, err)
 		}
 	}()
 
@@ -189,92 +331,205 @@ func invite(w http.ResponseWriter, r *http.Request) {
 		err = fmt.Errorf("bind request error, err=%w", err)
 		return
 	}
-	if rng.Start == "" || rng.End == "" {
+
+	src, err := newInviteSource(rng.Source, rng.Start, rng.End)
+	if err != nil {
 		statusCode = http.StatusBadRequest
 		err = fmt.Errorf("invalid params, range=%+v||err=%w", rng, err)
 		return
 	}
 
-	contents, err := SheetRangeContent(rng.Start, rng.End)
+	// 同一数据源已有任务在跑时返回该任务，避免重复邀请；是否在跑由 batches 的唯一索引判断，并发请求只有一个能创建
+	run, err := startBatch(r.Context(), src, rng.DryRun)
+	if errors.Is(err, ErrBatchRunning) {
+		running, err2 := runningBatch(r.Context(), src, rng.DryRun)
+		if err2 != nil {
+			statusCode, err = http.StatusInternalServerError, err2
+			return
+		}
+		job := JobCreated{Status: BatchStatusRunning}
+		if running != nil {
+			job.JobID, job.Status = running.ID, running.Status
+		}
+		if isV1(r) {
+			writeErrorDetails(w, r, http.StatusConflict, err, job)
+			return
+		}
+		writeJSON(w, r, http.StatusConflict, job)
+		return
+	}
 	if err != nil {
-		statusCode = http.StatusOK
-		err = fmt.Errorf("sheetRangeContent error, err=%w, contents=%v", err, contents)
+		statusCode = http.StatusInternalServerError
 		return
 	}
+	// 任务与请求解耦，客户端断开不影响处理，进度通过 GET /jobs/{id} 查询
+	go func() {
+		ctx := context.WithoutCancel(r.Context())
+		if err := run.execute(ctx, src); err != nil {
+			logrus.WithError(err).WithField("jobID", run.batchID).Errorf("%s source error", src.Name())
+		}
+		logrus.WithField("jobID", run.batchID).Infof("invite::processed=%d||len(invalid)=%d", run.processed(), len(run.invalid))
+	}()
+
+	writeJSON(w, r, http.StatusAccepted, JobCreated{JobID: run.batchID, Status: BatchStatusRunning})
+}
 
-	fmt.Printf("invite::len(content)=%d", len(contents))
+// inviteRun 累计一次邀请的结果，数据源每读出一块就回调一次 process
+type inviteRun struct {
+	source  string
+	batchID string
+	// dryRun 不为空时只计划，不邀请、不回写
+	dryRun      *dryRunState
+	successList []string
+	failedList  []string
+	skipped     []string
+	renewed     []string
+	invalid     []InvalidRow
+}
+
+func (run *inviteRun) processed() int {
+	return len(run.successList) + len(run.failedList) + len(run.skipped) + len(run.renewed) + len(run.invalid)
+}
+
+func (run *inviteRun) process(ctx context.Context, contents []Range, invalid []InvalidRow) error {
+	if run.dryRun == nil {
+		saveInvalidRows(ctx, run.source, invalid)
+	}
+	for _, row := range invalid {
+		logrus.WithFields(logrus.Fields{
+			"row":      row.Row,
+			"recordID": row.RecordID,
+			"reason":   row.Reason,
+		}).Warn("invalid_row")
+		if run.dryRun == nil {
+			writeBackStatus(ctx, Range{RecordID: row.RecordID}, InvitationStatusInvalid, row.Reason)
+		}
+	}
+	run.invalid = append(run.invalid, invalid...)
+	run.saveInvalidRows(ctx, invalid)
 
-	var successList, failedList, skipped []string
 	for _, content := range contents {
-		orderID := content.OrderID
-		githubName := content.GithubUsername
-		githubEmail := content.GithubEmail
-
-		if isMember, err := CheckIfUserIsMember(r.Context(), githubName); err != nil {
-			logrus.WithError(err).WithFields(logrus.Fields{
-				"orderID":     orderID,
-				"githubName":  githubName,
-				"githubEmail": githubEmail,
-			}).Error("check_error")
-		} else {
-			if isMember {
-				logrus.Infof("%s is member, skip", githubName)
-				skipped = append(skipped, githubName)
-				continue
-			}
+		if run.dryRun != nil {
+			outcome := run.dryRun.planOne(ctx, content)
+			run.count(content, outcome)
+			run.saveRow(ctx, content, outcome)
+			continue
 		}
+		outcome := inviteOne(ctx, content)
+		run.count(content, outcome)
+		writeBackStatus(ctx, content, outcome.writeBackStatus(), outcome.Reason)
+		run.saveRow(ctx, content, outcome)
+	}
 
-		inviteErr := InviteWrapper(r.Context(), orderID, githubName, githubEmail)
-		if inviteErr != nil {
-			if errors.Is(inviteErr, ErrAlreadyInvited) {
-				skipped = append(skipped, githubName)
-
-			} else {
-				failedList = append(failedList, githubName)
-				logrus.WithError(inviteErr).WithFields(logrus.Fields{
-					"orderID":     orderID,
-					"githubName":  githubName,
-					"githubEmail": githubEmail,
-				}).Error("invite_error")
-			}
-		} else {
-			successList = append(successList, githubName)
-			logrus.WithFields(logrus.Fields{
-				"orderID":     orderID,
-				"githubName":  githubName,
-				"githubEmail": githubEmail,
-			}).Info("invite_success")
+	return nil
+}
+
+// count 试运行时按计划的动作归类：invite 计入成功，reject 计入失败
+func (run *inviteRun) count(content Range, outcome Outcome) {
+	switch outcome.Result {
+	case OutcomeSucceeded:
+		run.successList = append(run.successList, content.GithubUsername)
+	case OutcomeRenewed:
+		run.renewed = append(run.renewed, content.GithubUsername)
+	case OutcomeSkipped:
+		run.skipped = append(run.skipped, content.GithubUsername)
+	default:
+		run.failedList = append(run.failedList, content.GithubUsername)
+	}
+}
+
+func (run *inviteRun) response() map[string]any {
+	return map[string]any{
+		"batch_id":    run.batchID,
+		"skipped":     run.skipped,
+		"renewed_cnt": len(run.renewed),
+		"renewedList": run.renewed,
+		"success_cnt": len(run.successList),
+		"successList": run.successList,
+		"failed_cnt":  len(run.failedList),
+		"failedList":  run.failedList,
+		"invalid_cnt": len(run.invalid),
+		"invalidList": run.invalid,
+	}
+}
+
+// RunResult /api/v1 中一次同步运行的结果，字段与 JobResponse 保持一致
+type RunResult struct {
+	JobID     string       `json:"job_id"`
+	Progress  JobProgress  `json:"progress"`
+	Succeeded []string     `json:"succeeded"`
+	Renewed   []string     `json:"renewed"`
+	Skipped   []string     `json:"skipped"`
+	Failed    []string     `json:"failed"`
+	Invalid   []InvalidRow `json:"invalid"`
+}
+
+func (run *inviteRun) result() *RunResult {
+	nonNil := func(s []string) []string {
+		if s == nil {
+			return []string{}
 		}
+		return s
+	}
+	r := &RunResult{
+		JobID: run.batchID,
+		Progress: JobProgress{
+			Done:      int32(run.processed()),
+			Succeeded: int32(len(run.successList)),
+			Renewed:   int32(len(run.renewed)),
+			Skipped:   int32(len(run.skipped)),
+			Failed:    int32(len(run.failedList)),
+			Invalid:   int32(len(run.invalid)),
+		},
+		Succeeded: nonNil(run.successList),
+		Renewed:   nonNil(run.renewed),
+		Skipped:   nonNil(run.skipped),
+		Failed:    nonNil(run.failedList),
+		Invalid:   run.invalid,
 	}
+	if r.Invalid == nil {
+		r.Invalid = []InvalidRow{}
+	}
+	return r
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(map[string]any{
-		"skipped":     skipped,
-		"success_cnt": len(successList),
-		"successList": successList,
-		"failed_cnt":  len(failedList),
-		"failedList":  failedList,
-	})
+// writeBackStatus 多维表格来源的行，把结果回写到对应记录
+func writeBackStatus(ctx context.Context, content Range, status, cause string) {
+	if content.RecordID == "" {
+		return
+	}
+	if err := BitableWriteStatus(ctx, content.RecordID, status, cause); err != nil {
+		logrus.WithError(err).WithField("recordID", content.RecordID).Error("bitable_write_back_error")
+	}
 }
 
-func InviteWrapper(ctx context.Context, orderID int64, username, email string) (err error) {
-	if cnt, err := query.InvitationModel.WithContext(ctx).Where(
-		query.InvitationModel.InvitationStatus.Eq(InvitationStatusSucceeded),
-		query.InvitationModel.GithubUsername.Eq(username),
-	).Count(); err == nil && cnt > 0 {
-		return ErrAlreadyInvited
+func InviteWrapper(ctx context.Context, content Range) (err error) {
+	var (
+		orderID  = content.OrderID
+		source   = content.orderSource()
+		username = content.GithubUsername
+		email    = content.GithubEmail
+	)
+	if err = checkInvited(ctx, content); err != nil {
+		return err
 	}
 	create := &model.InvitationModel{
 		ID:               uuid.New().String(),
 		OrderID:          orderID,
+		OrderSource:      source,
 		GithubUsername:   username,
 		GithubEmail:      email,
 		InvitationStatus: InvitationStatusPending,
+		RecordID:         content.RecordID,
 	}
-	// 最近一次未成功的
+	// 同一订单、同一用户最近一次未成功的
 	old, err := query.InvitationModel.WithContext(ctx).
-		Where(query.InvitationModel.InvitationStatus.Neq(InvitationStatusSucceeded)).
-		Or(query.InvitationModel.GithubUsername.Eq(username), query.InvitationModel.GithubEmail.Eq(email)).
+		Where(
+			query.InvitationModel.InvitationStatus.Neq(InvitationStatusSucceeded),
+			query.InvitationModel.OrderSource.Eq(source),
+			query.InvitationModel.OrderID.Eq(orderID),
+			query.InvitationModel.GithubUsername.Eq(username),
+		).
 		Order(query.InvitationModel.UpdatedAt.Desc()).
 		First()
 
@@ -294,8 +549,17 @@ func InviteWrapper(ctx context.Context, orderID int64, username, email string) (
 		create.GithubEmail = old.GithubEmail
 		create.InvitationStatus = old.InvitationStatus
 		create.FirstError = old.FirstError
+		if content.RecordID != "" && content.RecordID != old.RecordID {
+			create.RecordID = content.RecordID
+			if _, err = query.InvitationModel.WithContext(ctx).
+				Where(query.InvitationModel.ID.Eq(create.ID)).
+				UpdateColumnSimple(query.InvitationModel.RecordID.Value(create.RecordID)); err != nil {
+				return fmt.Errorf("update_record_id_error||err=%v||id=%s", err, create.ID)
+			}
+		}
 	}
 
+	var expiresAt time.Time
 	defer func() {
 		if errors.Is(err, ErrAlreadyInvited) {
 			err = nil
@@ -310,22 +574,91 @@ func InviteWrapper(ctx context.Context, orderID int64, username, email string) (
 			if errors.Unwrap(err) != nil {
 				cause = errors.Unwrap(err).Error()
 			}
+			if errors.Is(err, ErrSuspectedAbuse) {
+				status = InvitationStatusSuspectedAbuse
+			}
+		}
+		columns := []field.AssignExpr{
+			query.InvitationModel.InvitationStatus.Value(status),
+			query.InvitationModel.UpdatedAt.Value(time.Now()),
+		}
+		// first_error 只记第一次失败，last_error 记最近一次失败，成功后都保留
+		if cause != "" {
+			columns = append(columns, query.InvitationModel.ErrorClass.Value(classifyError(err)))
+			if create.FirstError == "" {
+				columns = append(columns, query.InvitationModel.FirstError.Value(cause))
+			}
+			columns = append(columns, query.InvitationModel.LastError.Value(cause))
+		}
+		// 重试成功后清掉失败分类，避免再被按分类筛选出来
+		if status == InvitationStatusSucceeded {
+			columns = append(columns, query.InvitationModel.ErrorClass.Value(""))
+		}
+		if status == InvitationStatusSucceeded && hasExpiry(expiresAt) {
+			columns = append(columns, query.InvitationModel.ExpiresAt.Value(expiresAt))
 		}
 		if _, err2 := query.InvitationModel.WithContext(ctx).
 			Where(query.InvitationModel.ID.Eq(create.ID)).
-			UpdateColumnSimple(
-				query.InvitationModel.InvitationStatus.Value(status),
-				query.InvitationModel.FirstError.Value(cause),
-			); err2 != nil {
+			UpdateColumnSimple(columns...); err2 != nil {
 			logrus.WithField("create", create).WithError(err2).Error("_db_create_error")
 		}
+		if err2 := query.InvitationAttemptModel.WithContext(ctx).Create(&model.InvitationAttemptModel{
+			ID:           uuid.New().String(),
+			InvitationID: create.ID,
+			Status:       status,
+			Error:        cause,
+		}); err2 != nil {
+			logrus.WithField("invitationID", create.ID).WithError(err2).Error("_db_create_attempt_error")
+		}
+		if status == InvitationStatusFailed || status == InvitationStatusSuspectedAbuse {
+			create.InvitationStatus = status
+			if err2 := notifier.InviteFailed(ctx, create, cause); err2 != nil {
+				logrus.WithField("create", create).WithError(err2).Error("notify_error")
+			}
+		}
 	}()
-	if !purchase(orderID) {
-		return fmt.Errorf("not purchased||orderID=%d||name=%s||email=%s")
+	bought, err := purchase(ctx, source, orderID)
+	if err != nil {
+		return fmt.Errorf("not purchased||orderID=%d||name=%s||email=%s||err=%w", orderID, username, email, err)
+	}
+	if err = checkSeats(ctx, username, bought); err != nil {
+		return fmt.Errorf("seat check failed||orderID=%d||name=%s||err=%w", orderID, username, err)
 	}
+	expiresAt = entitlementExpiry(content, bought, time.Now())
 	return Invite(username, email)
 }
 
+// checkInvited 按已有记录去重：已邀请、已忽略，或同一订单已到期移出
+func checkInvited(ctx context.Context, content Range) error {
+	var (
+		orderID  = content.OrderID
+		source   = content.orderSource()
+		username = content.GithubUsername
+	)
+	if cnt, err := query.InvitationModel.WithContext(ctx).Where(
+		query.InvitationModel.InvitationStatus.In(InvitationStatusSucceeded, InvitationStatusResolved),
+		query.InvitationModel.GithubUsername.Eq(username),
+	).Count(); err == nil && cnt > 0 {
+		return ErrAlreadyInvited
+	}
+	if cnt, err := query.InvitationModel.WithContext(ctx).Where(
+		query.InvitationModel.InvitationStatus.In(InvitationStatusIgnored, InvitationStatusRevoked),
+		query.InvitationModel.GithubUsername.Eq(username),
+	).Count(); err == nil && cnt > 0 {
+		return ErrIgnored
+	}
+	// 到期移出后，同一订单不再重新邀请，需要续费的新订单
+	if cnt, err := query.InvitationModel.WithContext(ctx).Where(
+		query.InvitationModel.InvitationStatus.Eq(InvitationStatusExpired),
+		query.InvitationModel.OrderSource.Eq(source),
+		query.InvitationModel.OrderID.Eq(orderID),
+		query.InvitationModel.GithubUsername.Eq(username),
+	).Count(); err == nil && cnt > 0 {
+		return ErrEntitlementExpired
+	}
+	return nil
+}
+
 func MustGetEnvs() (err error) {
 	for key := range lazyInit {
 		if value, exist := os.LookupEnv(key); !exist || value == "" {
@@ -337,18 +670,52 @@ func MustGetEnvs() (err error) {
 			reflect.ValueOf(ptr).Elem().SetString(value)
 		}
 	}
+	for key, ptr := range lazyInitOptional {
+		if value, exist := os.LookupEnv(key); exist {
+			reflect.ValueOf(ptr).Elem().SetString(value)
+		}
+	}
 	return nil
 }
 
-func callInviteEndpoint() {
-	body := strings.NewReader(`{"start":"A2","end":"C"}`)
-	resp, err := http.Post("http://localhost:8182/invite", "application/json", body)
+// sheetCronPayload 定时任务读取的表格范围，来自 feishu.range；第 4 列为到期时间时把 end 配成 D
+func sheetCronPayload() string {
+	rng := map[string]string{"start": "A2", "end": "C"}
+	if v := viper.GetString("feishu.range.start"); v != "" {
+		rng["start"] = v
+	}
+	if v := viper.GetString("feishu.range.end"); v != "" {
+		rng["end"] = v
+	}
+	payload, _ := json.Marshal(rng)
+	return string(payload)
+}
+
+// inviteEndpointClient /invite 只创建任务后立即返回，不需要长时间等待
+var inviteEndpointClient = &http.Client{Timeout: 30 * time.Second}
+
+func callInviteEndpoint(payload string) {
+	req, err := http.NewRequest(http.MethodPost, "http://localhost:8182/invite", strings.NewReader(payload))
+	if err != nil {
+		logrus.WithError(err).Error("failed to build invite request")
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+internalAPIKey)
+	resp, err := inviteEndpointClient.Do(req)
 	if err != nil {
 		logrus.WithError(err).Error("failed to call invite endpoint")
 		return
 	}
 	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
+	var job JobCreated
+	_ = json.NewDecoder(resp.Body).Decode(&job)
+	switch resp.StatusCode {
+	case http.StatusAccepted:
+		logrus.WithField("jobID", job.JobID).Info("invite job started")
+	case http.StatusConflict:
+		logrus.WithField("jobID", job.JobID).Warn("invite job still running, skip")
+	default:
 		logrus.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 }
@@ -0,0 +1,350 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 没有引入 prometheus 客户端库，按文本格式（0.0.4）手写输出，只实现用到的几种指标
+
+var (
+	metricInvites = newCounterVec("autobot_invites_total",
+		"邀请结果，按 result 和失败、跳过原因分类", "result", "class")
+	metricRunDuration = newHistogramVec("autobot_invite_run_duration_seconds",
+		"每次运行（批次）的耗时", []float64{1, 5, 15, 30, 60, 120, 300, 600, 1800},
+		"source", "status", "dry_run")
+	metricRowsProcessed = newCounterVec("autobot_invite_rows_processed_total",
+		"批次处理的行数，包含校验失败的行", "source", "result", "dry_run")
+	metricUpstreamDuration = newHistogramVec("autobot_upstream_request_duration_seconds",
+		"调用 GitHub、飞书、爱发电、会员购的耗时，status 为 HTTP 状态码，请求未完成时为 error",
+		[]float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		"upstream", "endpoint", "status")
+	metricGithubRateLimit = newGaugeVec("autobot_github_rate_limit_remaining",
+		"最近一次 GitHub 响应中的剩余请求额度", "resource")
+)
+
+// metric 一个指标族，scrape 时写出 HELP、TYPE 和所有样本
+type metric interface {
+	writeTo(w io.Writer)
+}
+
+var (
+	metricsMu sync.Mutex
+	metrics   []metric
+)
+
+func registerMetric(m metric) {
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+	metrics = append(metrics, m)
+}
+
+// serveMetrics GET /metrics
+func serveMetrics(w http.ResponseWriter, _ *http.Request) {
+	metricsMu.Lock()
+	registered := slices.Clone(metrics)
+	metricsMu.Unlock()
+
+	var buf bytes.Buffer
+	for _, m := range registered {
+		m.writeTo(&buf)
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write(buf.Bytes())
+}
+
+// metricVec 按标签值区分的样本，key 为标签值以 \xff 连接
+type metricVec[T any] struct {
+	name   string
+	help   string
+	labels []string
+
+	mu      sync.Mutex
+	samples map[string]*T
+	values  map[string][]string
+}
+
+func (v *metricVec[T]) with(values []string, init func() *T) *T {
+	if len(values) != len(v.labels) {
+		panic(fmt.Sprintf("metric %s: want %d label values, got %d", v.name, len(v.labels), len(values)))
+	}
+	key := strings.Join(values, "\xff")
+	s, ok := v.samples[key]
+	if !ok {
+		s = init()
+		v.samples[key] = s
+		v.values[key] = slices.Clone(values)
+	}
+	return s
+}
+
+// each 按标签值排序遍历，保证输出稳定
+func (v *metricVec[T]) each(fn func(labels string, s *T)) {
+	keys := make([]string, 0, len(v.samples))
+	for k := range v.samples {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		fn(formatLabels(v.labels, v.values[k]), v.samples[k])
+	}
+}
+
+func (v *metricVec[T]) writeHeader(w io.Writer, typ string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", v.name, v.help, v.name, typ)
+}
+
+func newMetricVec[T any](name, help string, labels []string) metricVec[T] {
+	return metricVec[T]{name: name, help: help, labels: labels, samples: make(map[string]*T), values: make(map[string][]string)}
+}
+
+func newSample() *float64 { return new(float64) }
+
+type counterVec struct {
+	metricVec[float64]
+}
+
+func newCounterVec(name, help string, labels ...string) *counterVec {
+	c := &counterVec{newMetricVec[float64](name, help, labels)}
+	registerMetric(c)
+	return c
+}
+
+func (c *counterVec) inc(values ...string) {
+	c.add(1, values...)
+}
+
+func (c *counterVec) add(n float64, values ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	*c.with(values, newSample) += n
+}
+
+func (c *counterVec) writeTo(w io.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.writeHeader(w, "counter")
+	c.each(func(labels string, v *float64) {
+		fmt.Fprintf(w, "%s%s %s\n", c.name, labels, formatFloat(*v))
+	})
+}
+
+type gaugeVec struct {
+	metricVec[float64]
+}
+
+func newGaugeVec(name, help string, labels ...string) *gaugeVec {
+	g := &gaugeVec{newMetricVec[float64](name, help, labels)}
+	registerMetric(g)
+	return g
+}
+
+func (g *gaugeVec) set(n float64, values ...string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	*g.with(values, newSample) = n
+}
+
+func (g *gaugeVec) writeTo(w io.Writer) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.writeHeader(w, "gauge")
+	g.each(func(labels string, v *float64) {
+		fmt.Fprintf(w, "%s%s %s\n", g.name, labels, formatFloat(*v))
+	})
+}
+
+type histogram struct {
+	counts []uint64 // 与 buckets 一一对应，不累加
+	sum    float64
+	count  uint64
+}
+
+type histogramVec struct {
+	metricVec[histogram]
+	buckets []float64
+}
+
+func newHistogramVec(name, help string, buckets []float64, labels ...string) *histogramVec {
+	h := &histogramVec{newMetricVec[histogram](name, help, labels), buckets}
+	registerMetric(h)
+	return h
+}
+
+func (h *histogramVec) observe(v float64, values ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s := h.with(values, func() *histogram { return &histogram{counts: make([]uint64, len(h.buckets))} })
+	if i, _ := slices.BinarySearch(h.buckets, v); i < len(h.buckets) {
+		s.counts[i]++
+	}
+	s.sum += v
+	s.count++
+}
+
+func (h *histogramVec) since(start time.Time, values ...string) {
+	h.observe(time.Since(start).Seconds(), values...)
+}
+
+func (h *histogramVec) writeTo(w io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.writeHeader(w, "histogram")
+	h.each(func(labels string, s *histogram) {
+		var cumulative uint64
+		for i, le := range h.buckets {
+			cumulative += s.counts[i]
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, withLabel(labels, "le", formatFloat(le)), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, withLabel(labels, "le", "+Inf"), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, labels, formatFloat(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, labels, s.count)
+	})
+}
+
+// funcMetric scrape 时才取值的单个样本，如连接池状态
+type funcMetric struct {
+	name  string
+	help  string
+	typ   string
+	value func() float64
+}
+
+func (m *funcMetric) writeTo(w io.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %s\n", m.name, m.help, m.name, m.typ, m.name, formatFloat(m.value()))
+}
+
+// registerDBMetrics 暴露 store 包创建的连接池状态
+func registerDBMetrics(db *gorm.DB) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return
+	}
+	for _, m := range []*funcMetric{
+		{"autobot_db_max_open_connections", "连接池允许的最大连接数", "gauge",
+			func() float64 { return float64(sqlDB.Stats().MaxOpenConnections) }},
+		{"autobot_db_open_connections", "当前打开的连接数", "gauge",
+			func() float64 { return float64(sqlDB.Stats().OpenConnections) }},
+		{"autobot_db_in_use_connections", "正在使用的连接数", "gauge",
+			func() float64 { return float64(sqlDB.Stats().InUse) }},
+		{"autobot_db_idle_connections", "空闲连接数", "gauge",
+			func() float64 { return float64(sqlDB.Stats().Idle) }},
+		{"autobot_db_wait_count_total", "等待空闲连接的总次数", "counter",
+			func() float64 { return float64(sqlDB.Stats().WaitCount) }},
+		{"autobot_db_wait_duration_seconds_total", "等待空闲连接的总耗时", "counter",
+			func() float64 { return sqlDB.Stats().WaitDuration.Seconds() }},
+	} {
+		registerMetric(m)
+	}
+}
+
+func formatLabels(names, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + `="` + labelEscaper.Replace(values[i]) + `"`
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func withLabel(labels, name, value string) string {
+	pair := name + `="` + value + `"`
+	if labels == "" {
+		return "{" + pair + "}"
+	}
+	return labels[:len(labels)-1] + "," + pair + "}"
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+const (
+	upstreamGithub = "github"
+	upstreamFeishu = "feishu"
+	upstreamAfdian = "afdian"
+	upstreamMall   = "bilibili_mall"
+)
+
+// instrumentedTransport 记录上游请求的耗时；GitHub 响应同时更新剩余额度
+type instrumentedTransport struct {
+	upstream string
+	// endpoint 把路径中的用户名、表格 token 等替换为占位符，避免标签值无限增长
+	endpoint func(path string) string
+}
+
+// newInstrumentedClient timeout 为 0 时不限制，由调用方的 context 控制
+func newInstrumentedClient(upstream string, timeout time.Duration, endpoint func(path string) string) *http.Client {
+	return &http.Client{Transport: &instrumentedTransport{upstream: upstream, endpoint: endpoint}, Timeout: timeout}
+}
+
+// plainEndpoint 路径中没有参数的上游直接使用路径
+func plainEndpoint(path string) string { return path }
+
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+		// 其他上游也可能返回同名的头，只记 GitHub 的额度
+		if v := resp.Header.Get("X-RateLimit-Remaining"); v != "" && t.upstream == upstreamGithub {
+			if remaining, err := strconv.ParseFloat(v, 64); err == nil {
+				resource := resp.Header.Get("X-RateLimit-Resource")
+				if resource == "" {
+					resource = "core"
+				}
+				metricGithubRateLimit.set(remaining, resource)
+			}
+		}
+	}
+	metricUpstreamDuration.since(start, t.upstream, req.Method+" "+t.endpoint(req.URL.Path), status)
+	return resp, err
+}
+
+// githubPathParams 这些路径段之后的一段是参数
+var githubPathParams = map[string]string{
+	"orgs":        "{org}",
+	"users":       "{username}",
+	"members":     "{username}",
+	"memberships": "{username}",
+	"invitations": "{invitation_id}",
+}
+
+// githubEndpoint 如 /orgs/{org}/members/{username}
+func githubEndpoint(path string) string {
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		if param, ok := githubPathParams[segments[i-1]]; ok && segments[i] != "" {
+			segments[i] = param
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+var feishuPathWord = regexp.MustCompile(`^([a-z_-]+|v\d+)$`)
+
+// feishuEndpoint 飞书路径中的 token、ID 和范围都含大写字母、数字或符号，如 /open-apis/sheets/v2/spreadsheets/{id}/values/{id}
+func feishuEndpoint(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if segment != "" && !feishuPathWord.MatchString(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
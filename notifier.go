@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/model"
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+	larkim "github.com/larksuite/oapi-sdk-go/v3/service/im/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// Notifier 把需要人工处理的事件推送给运营
+type Notifier interface {
+	InviteFailed(ctx context.Context, invitation *model.InvitationModel, cause string) error
+	// EntitlementExpiring 会员即将到期
+	EntitlementExpiring(ctx context.Context, invitation *model.InvitationModel) error
+	// EntitlementExpired 会员已到期，cause 非空表示移出组织失败
+	EntitlementExpired(ctx context.Context, invitation *model.InvitationModel, cause string) error
+}
+
+var notifier Notifier = nopNotifier{}
+
+type nopNotifier struct{}
+
+func (nopNotifier) InviteFailed(context.Context, *model.InvitationModel, string) error { return nil }
+func (nopNotifier) EntitlementExpiring(context.Context, *model.InvitationModel) error  { return nil }
+func (nopNotifier) EntitlementExpired(context.Context, *model.InvitationModel, string) error {
+	return nil
+}
+
+// setupNotifier 配置了 feishu.notify.chat_id 时，把通知以消息卡片发到该群
+func setupNotifier() {
+	chatID := viper.GetString("feishu.notify.chat_id")
+	if chatID == "" {
+		logrus.Warn("feishu.notify.chat_id is empty, notifications disabled")
+		return
+	}
+	notifier = &feishuNotifier{chatID: chatID}
+}
+
+type feishuNotifier struct {
+	chatID string
+}
+
+func (n *feishuNotifier) InviteFailed(ctx context.Context, invitation *model.InvitationModel, cause string) error {
+	return n.sendCard(ctx, inviteFailedCard(invitation, cause))
+}
+
+func (n *feishuNotifier) EntitlementExpiring(ctx context.Context, invitation *model.InvitationModel) error {
+	cause := fmt.Sprintf("将于 %s 到期", invitation.ExpiresAt.Format(time.DateTime))
+	return n.sendCard(ctx, entitlementCard("orange", "GitHub 组织会员即将到期", invitation, cause))
+}
+
+func (n *feishuNotifier) EntitlementExpired(ctx context.Context, invitation *model.InvitationModel, cause string) error {
+	if cause != "" {
+		return n.sendCard(ctx, entitlementCard("red", "GitHub 组织会员已到期，移出失败", invitation, cause))
+	}
+	cause = fmt.Sprintf("已于 %s 到期，已移出组织", invitation.ExpiresAt.Format(time.DateTime))
+	return n.sendCard(ctx, entitlementCard("grey", "GitHub 组织会员已到期", invitation, cause))
+}
+
+func (n *feishuNotifier) sendCard(ctx context.Context, card map[string]any) error {
+	content, err := json.Marshal(card)
+	if err != nil {
+		return fmt.Errorf("marshal card error||err=%w", err)
+	}
+	feishuTenantAccessToken, err := acquireFeishuTenantAccessToken()
+	if err != nil {
+		return fmt.Errorf("error acquiring tenant access token||err=%w", err)
+	}
+	req := larkim.NewCreateMessageReqBuilder().
+		ReceiveIdType(larkim.ReceiveIdTypeChatId).
+		Body(larkim.NewCreateMessageReqBodyBuilder().
+			ReceiveId(n.chatID).
+			MsgType(larkim.MsgTypeInteractive).
+			Content(string(content)).
+			Build()).
+		Build()
+	resp, err := newFeishuClient().Im.V1.Message.Create(ctx, req, larkcore.WithTenantAccessToken(feishuTenantAccessToken))
+	if err != nil {
+		return fmt.Errorf("send card error||err=%w", err)
+	}
+	if !resp.Success() {
+		return fmt.Errorf("logId: %s, error response: \n%s", resp.RequestId(), larkcore.Prettify(resp.CodeError))
+	}
+	return nil
+}
+
+func inviteFailedCard(invitation *model.InvitationModel, cause string) map[string]any {
+	button := func(text, kind, action string) map[string]any {
+		return map[string]any{
+			"tag":  "button",
+			"text": map[string]any{"tag": "plain_text", "content": text},
+			"type": kind,
+			"value": map[string]any{
+				"action":        action,
+				"invitation_id": invitation.ID,
+			},
+		}
+	}
+	return map[string]any{
+		"config": map[string]any{"wide_screen_mode": true},
+		"header": map[string]any{
+			"template": "red",
+			"title":    map[string]any{"tag": "plain_text", "content": "GitHub 组织邀请失败"},
+		},
+		"elements": []any{
+			invitationCardFields(invitation, cause),
+			map[string]any{
+				"tag": "action",
+				"actions": []any{
+					button("重试", "primary", CardActionRetry),
+					button("忽略", "default", CardActionIgnore),
+					button("标记为已解决", "default", CardActionResolve),
+				},
+			},
+		},
+	}
+}
+
+func entitlementCard(template, title string, invitation *model.InvitationModel, cause string) map[string]any {
+	return map[string]any{
+		"config": map[string]any{"wide_screen_mode": true},
+		"header": map[string]any{
+			"template": template,
+			"title":    map[string]any{"tag": "plain_text", "content": title},
+		},
+		"elements": []any{invitationCardFields(invitation, cause)},
+	}
+}
+
+func invitationCardFields(invitation *model.InvitationModel, cause string) map[string]any {
+	return map[string]any{
+		"tag": "div",
+		"text": map[string]any{
+			"tag": "lark_md",
+			"content": fmt.Sprintf("**订单号**：%d\n**GitHub 用户名**：%s\n**GitHub 邮箱**：%s\n**原因**：%s",
+				invitation.OrderID, invitation.GithubUsername, invitation.GithubEmail, cause),
+		},
+	}
+}
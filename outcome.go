@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	OutcomeSucceeded = "succeeded"
+	OutcomeRenewed   = "renewed"
+	OutcomeSkipped   = "skipped"
+	OutcomeFailed    = "failed"
+	// OutcomeInvalid 只用于批次的行结果，校验失败的行
+	OutcomeInvalid = "invalid"
+
+	// 跳过或失败的原因分类
+	ClassAlreadyMember      = "already_member"
+	ClassAlreadyInvited     = "already_invited"
+	ClassIgnored            = "ignored"
+	ClassBlocked            = "blocked"
+	ClassEntitlementExpired = "entitlement_expired"
+	ClassAlreadyRenewed     = "already_renewed"
+	ClassNotPurchased       = "not_purchased"
+	ClassSuspectedAbuse     = "suspected_abuse"
+	ClassUserNotFound       = "user_not_found"
+	ClassInviteRejected     = "invite_rejected"
+	ClassRateLimited        = "rate_limited"
+	ClassError              = "error"
+)
+
+// permanentClasses 重试也不会成功的失败分类，批量重试时需要 force
+var permanentClasses = []string{ClassNotPurchased, ClassUserNotFound, ClassInviteRejected}
+
+// Outcome 单行邀请的结果
+type Outcome struct {
+	Result string `json:"result"`
+	// Action 试运行时计划的动作，见 PlanInvite 等
+	Action string `json:"action,omitempty"`
+	Class  string `json:"class,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// classifyError 按哨兵错误归类，其余归为 error
+func classifyError(err error) string {
+	switch {
+	case errors.Is(err, ErrAlreadyInvited):
+		return ClassAlreadyInvited
+	case errors.Is(err, ErrIgnored):
+		return ClassIgnored
+	case errors.Is(err, ErrBlocked):
+		return ClassBlocked
+	case errors.Is(err, ErrEntitlementExpired):
+		return ClassEntitlementExpired
+	case errors.Is(err, ErrAlreadyRenewed):
+		return ClassAlreadyRenewed
+	case errors.Is(err, ErrSuspectedAbuse):
+		return ClassSuspectedAbuse
+	case errors.Is(err, ErrNotPurchased):
+		return ClassNotPurchased
+	case errors.Is(err, ErrGithubUserNotFound):
+		return ClassUserNotFound
+	case errors.Is(err, ErrInviteRejected):
+		return ClassInviteRejected
+	case errors.Is(err, ErrInviteRateLimited):
+		return ClassRateLimited
+	}
+	return ClassError
+}
+
+func outcomeOf(err error) Outcome {
+	class := classifyError(err)
+	switch class {
+	case ClassAlreadyInvited, ClassIgnored, ClassBlocked, ClassEntitlementExpired, ClassAlreadyRenewed:
+		return Outcome{Result: OutcomeSkipped, Class: class, Reason: err.Error()}
+	}
+	return Outcome{Result: OutcomeFailed, Class: class, Reason: err.Error()}
+}
+
+// writeBackStatus 回写到数据源的状态
+func (o Outcome) writeBackStatus() string {
+	if o.Class == ClassBlocked {
+		return InvitationStatusBlocked
+	}
+	switch o.Result {
+	case OutcomeSucceeded:
+		return InvitationStatusSucceeded
+	case OutcomeRenewed:
+		return InvitationStatusRenewed
+	case OutcomeSkipped:
+		return InvitationStatusSkipped
+	}
+	if o.Class == ClassSuspectedAbuse {
+		return InvitationStatusSuspectedAbuse
+	}
+	return InvitationStatusFailed
+}
+
+// inviteOne 单行的完整邀请流程：名单检查、续费、成员检查，再走 InviteWrapper
+func inviteOne(ctx context.Context, content Range) (outcome Outcome) {
+	defer func() { metricInvites.inc(outcome.Result, outcome.Class) }()
+	logger := logrus.WithFields(logrus.Fields{
+		"orderID":     content.OrderID,
+		"orderSource": content.orderSource(),
+		"githubName":  content.GithubUsername,
+		"githubEmail": content.GithubEmail,
+	})
+
+	// 名单检查在所有 GitHub 调用之前，命中封禁名单的行不续费也不邀请
+	if err := checkAccess(ctx, content); err != nil {
+		outcome := outcomeOf(err)
+		if outcome.Result == OutcomeFailed {
+			logger.WithError(err).Error("check_access_error")
+		} else {
+			logger.WithError(err).Warn("invite_blocked")
+		}
+		return outcome
+	}
+
+	// 续费行在成员检查之前处理，已在组织中的会员也要延长到期时间
+	renewed, err := renewEntitlement(ctx, content)
+	switch {
+	case err != nil:
+		outcome := outcomeOf(err)
+		if outcome.Result == OutcomeFailed {
+			logger.WithError(err).Error("renew_error")
+		}
+		return outcome
+	case renewed:
+		return Outcome{Result: OutcomeRenewed}
+	}
+
+	if isMember, err := CheckIfUserIsMember(ctx, content.GithubUsername); err != nil {
+		logger.WithError(err).Error("check_error")
+	} else if isMember {
+		logrus.Infof("%s is member, skip", content.GithubUsername)
+		return Outcome{Result: OutcomeSkipped, Class: ClassAlreadyMember, Reason: "already a member"}
+	}
+
+	if err = InviteWrapper(ctx, content); err != nil {
+		outcome := outcomeOf(err)
+		if outcome.Result == OutcomeFailed {
+			logger.WithError(err).Error("invite_error")
+		}
+		return outcome
+	}
+	logger.Info("invite_success")
+	return Outcome{Result: OutcomeSucceeded}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	type step struct {
+		key string
+		// elapse 在这一步之前经过的时间
+		elapse time.Duration
+		allow  bool
+	}
+	tests := []struct {
+		name  string
+		limit int
+		steps []step
+	}{
+		{
+			name:  "limit within window",
+			limit: 2,
+			steps: []step{{key: "a", allow: true}, {key: "a", allow: true}, {key: "a"}, {key: "a", elapse: 30 * time.Second}},
+		},
+		{
+			name:  "keys are independent",
+			limit: 1,
+			steps: []step{{key: "a", allow: true}, {key: "b", allow: true}, {key: "a"}, {key: "b"}},
+		},
+		{
+			name:  "new window after expiry",
+			limit: 1,
+			steps: []step{{key: "a", allow: true}, {key: "a"}, {key: "a", elapse: time.Minute, allow: true}, {key: "a"}},
+		},
+		{
+			name:  "rejected requests do not extend the window",
+			limit: 1,
+			steps: []step{{key: "a", allow: true}, {key: "a", elapse: 50 * time.Second}, {key: "a", elapse: 10 * time.Second, allow: true}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newRateLimiter(tt.limit, time.Minute)
+			for i, s := range tt.steps {
+				// 把已有窗口的开始时间往前移，模拟经过的时间
+				for _, w := range l.windows {
+					w.start = w.start.Add(-s.elapse)
+				}
+				l.lastSweep = l.lastSweep.Add(-s.elapse)
+
+				allowed, wait := l.allow(s.key)
+				if allowed != s.allow {
+					t.Fatalf("step %d: allow(%q) = %v, want %v", i, s.key, allowed, s.allow)
+				}
+				if !allowed && (wait <= 0 || wait > time.Minute) {
+					t.Errorf("step %d: wait = %v, want within the window", i, wait)
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimiterSweep(t *testing.T) {
+	l := newRateLimiter(1, time.Minute)
+	l.allow("a")
+	l.allow("b")
+	for _, w := range l.windows {
+		w.start = w.start.Add(-2 * time.Minute)
+	}
+	l.lastSweep = l.lastSweep.Add(-2 * time.Minute)
+	l.allow("c")
+	if _, ok := l.windows["a"]; ok || len(l.windows) != 1 {
+		t.Errorf("windows = %v, want only c after the sweep", l.windows)
+	}
+}
@@ -0,0 +1,326 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/model"
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/query"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+const (
+	defaultRetryLimit = 50
+	maxRetryLimit     = 200
+
+	// OutcomeRefused 永久性失败且未指定 force，没有重试
+	OutcomeRefused = "refused"
+	// OutcomeQueued 已放入后台任务，结果见任务中对应的行
+	OutcomeQueued = "queued"
+
+	// syncRetryLimit 按 ids 重试且不超过这么多条时直接重试，响应中就是每条的结果
+	syncRetryLimit = 5
+
+	// SourceRetry 批量重试在后台任务中运行，同时只有一个
+	SourceRetry = "retry"
+
+	// stalePendingAfter PENDING 超过这么久没有更新，说明邀请没有完成（如进程退出），与 FAILED 一样可以重试
+	stalePendingAfter = 10 * time.Minute
+)
+
+// RetryRequest POST /invitations/retry 的筛选条件，只匹配 FAILED 和长时间停留在 PENDING 的记录：
+// ids 为邀请 ID，classes 为失败分类，from、to 按最近更新时间筛选（格式同 ListParams），
+// all 为 true 时匹配全部可重试的记录；至少指定一个条件
+type RetryRequest struct {
+	IDs     []string `json:"ids"`
+	Classes []string `json:"classes"`
+	From    string   `json:"from"`
+	To      string   `json:"to"`
+	All     bool     `json:"all"`
+	// Force 为 true 时永久性失败（见 permanentClasses）也重试
+	Force bool `json:"force"`
+	// Limit 本次最多重试的条数，默认 50，最大 200
+	Limit int `json:"limit"`
+}
+
+// RetryResult 单条记录的重试结果，Class 为重试前的失败分类；
+// 放入后台任务的记录 Outcome 为 queued，Row 为任务中对应行的 row_number
+type RetryResult struct {
+	ID             string  `json:"id"`
+	OrderID        int64   `json:"order_id"`
+	GithubUsername string  `json:"github_username"`
+	Class          string  `json:"class"`
+	Row            int     `json:"row,omitempty"`
+	Outcome        Outcome `json:"outcome"`
+}
+
+// RetryResponse Total 为满足条件的记录数，超过 limit 的部分需要再次调用；Results 包含每一条匹配的记录。
+// 按 ids 重试不超过 syncRetryLimit 条时直接重试，Results 为最终结果；
+// 否则可以重试的记录在后台任务 JobID 中逐条重试，结果见 GET /jobs/{id} 中 row_number 为 Row 的行
+type RetryResponse struct {
+	Total   int64          `json:"total"`
+	JobID   string         `json:"job_id,omitempty"`
+	Queued  int            `json:"queued"`
+	Results []*RetryResult `json:"results"`
+}
+
+// retrySource 把选中的 FAILED 记录作为数据源，走与表格相同的邀请流程并记录为批次；
+// detail 为筛选条件，相同条件的重试同时只有一个
+type retrySource struct {
+	detail      string
+	invitations []*model.InvitationModel
+}
+
+func (s *retrySource) Name() string   { return SourceRetry }
+func (s *retrySource) Detail() string { return s.detail }
+func (s *retrySource) Each(ctx context.Context, fn func(context.Context, []Range, []InvalidRow) error) error {
+	contents := make([]Range, 0, len(s.invitations))
+	for i, invitation := range s.invitations {
+		content := invitationContent(invitation)
+		content.Row = i + 1
+		contents = append(contents, content)
+	}
+	return fn(ctx, contents, nil)
+}
+
+// detail 筛选条件的文本，ids 较多，只保留数量和摘要
+func (req RetryRequest) detail() string {
+	var parts []string
+	if len(req.IDs) > 0 {
+		ids := slices.Sorted(slices.Values(req.IDs))
+		sum := sha256.Sum256([]byte(strings.Join(ids, ",")))
+		parts = append(parts, fmt.Sprintf("ids=%d:%s", len(ids), hex.EncodeToString(sum[:4])))
+	}
+	if len(req.Classes) > 0 {
+		parts = append(parts, "classes="+strings.Join(slices.Sorted(slices.Values(req.Classes)), ","))
+	}
+	if req.From != "" {
+		parts = append(parts, "from="+req.From)
+	}
+	if req.To != "" {
+		parts = append(parts, "to="+req.To)
+	}
+	if req.All {
+		parts = append(parts, "all")
+	}
+	if req.Force {
+		parts = append(parts, "force")
+	}
+	return strings.Join(parts, " ")
+}
+
+// retryableCondition FAILED，或 PENDING 且超过 stalePendingAfter 没有更新
+func retryableCondition() gen.Condition {
+	t := query.InvitationModel
+	return field.Or(
+		t.InvitationStatus.Eq(InvitationStatusFailed),
+		field.And(t.InvitationStatus.Eq(InvitationStatusPending), t.UpdatedAt.Lt(time.Now().Add(-stalePendingAfter))),
+	)
+}
+
+// retryable 与 retryableCondition 相同的判断
+func retryable(invitation *model.InvitationModel) bool {
+	switch invitation.InvitationStatus {
+	case InvitationStatusFailed:
+		return true
+	case InvitationStatusPending:
+		return time.Since(invitation.UpdatedAt) > stalePendingAfter
+	}
+	return false
+}
+
+// failedClass 分类为空的是记录分类之前的失败，按 error 处理
+func failedClass(invitation *model.InvitationModel) string {
+	if invitation.ErrorClass == "" {
+		return ClassError
+	}
+	return invitation.ErrorClass
+}
+
+// retryFailed 把匹配的 FAILED 记录逐条重新走一遍邀请流程：少量按 ids 指定的记录直接重试，其余放到后台任务中，立即返回任务 ID
+func retryFailed(w http.ResponseWriter, r *http.Request) {
+	var (
+		err        error
+		statusCode = http.StatusOK
+		req        RetryRequest
+		t          = query.InvitationModel
+	)
+	defer func() {
+		if err != nil {
+			writeError(w, r, statusCode, err)
+		}
+	}()
+
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		statusCode = http.StatusBadRequest
+		err = fmt.Errorf("bind request error, err=%w", err)
+		return
+	}
+	if len(req.IDs) == 0 && len(req.Classes) == 0 && req.From == "" && req.To == "" && !req.All {
+		statusCode = http.StatusBadRequest
+		err = errors.New("invalid params, one of ids, classes, from, to or all is required")
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultRetryLimit
+	}
+	if req.Limit < 0 || req.Limit > maxRetryLimit {
+		statusCode = http.StatusBadRequest
+		err = fmt.Errorf("invalid params, limit must be 1-%d", maxRetryLimit)
+		return
+	}
+
+	conds := []gen.Condition{retryableCondition()}
+	if len(req.IDs) > 0 {
+		for _, id := range req.IDs {
+			if err = uuid.Validate(id); err != nil {
+				statusCode = http.StatusBadRequest
+				err = fmt.Errorf("invalid params, id=%s", id)
+				return
+			}
+		}
+		conds = append(conds, t.ID.In(req.IDs...))
+	}
+	if len(req.Classes) > 0 {
+		for _, class := range req.Classes {
+			if slices.Contains(permanentClasses, class) && !req.Force {
+				statusCode = http.StatusBadRequest
+				err = fmt.Errorf("class %s is permanent, set force to retry", class)
+				return
+			}
+		}
+		classes := req.Classes
+		if slices.Contains(classes, ClassError) {
+			classes = append(classes, "")
+		}
+		conds = append(conds, t.ErrorClass.In(classes...))
+	}
+	var from, to time.Time
+	if from, err = parseListTime(req.From, false); err != nil {
+		statusCode = http.StatusBadRequest
+		err = fmt.Errorf("invalid from: %w", err)
+		return
+	}
+	if to, err = parseListTime(req.To, true); err != nil {
+		statusCode = http.StatusBadRequest
+		err = fmt.Errorf("invalid to: %w", err)
+		return
+	}
+	if !from.IsZero() {
+		conds = append(conds, t.UpdatedAt.Gte(from))
+	}
+	if !to.IsZero() {
+		conds = append(conds, t.UpdatedAt.Lt(to))
+	}
+	// 只有明确指定的 ID 会逐条报告 refused，按条件批量筛选时直接排除永久性失败
+	if len(req.IDs) == 0 && !req.Force {
+		conds = append(conds, t.ErrorClass.NotIn(permanentClasses...))
+	}
+
+	do := t.WithContext(r.Context()).Where(conds...)
+	total, err := do.Count()
+	if err != nil {
+		statusCode = http.StatusInternalServerError
+		err = fmt.Errorf("count_failed_error||err=%v", err)
+		return
+	}
+	invitations, err := do.Order(t.UpdatedAt, t.ID).Limit(req.Limit).Find()
+	if err != nil {
+		statusCode = http.StatusInternalServerError
+		err = fmt.Errorf("find_failed_error||err=%v", err)
+		return
+	}
+
+	resp := RetryResponse{Total: total, Results: make([]*RetryResult, 0, len(invitations))}
+	if len(req.IDs) > 0 && len(req.IDs) <= syncRetryLimit {
+		for _, invitation := range invitations {
+			resp.Results = append(resp.Results, retryOne(r.Context(), invitation, req.Force))
+		}
+		writeJSON(w, r, http.StatusOK, resp)
+		return
+	}
+
+	src := &retrySource{detail: req.detail()}
+	for _, invitation := range invitations {
+		if result := refuseRetry(invitation, req.Force); result != nil {
+			resp.Results = append(resp.Results, result)
+			continue
+		}
+		src.invitations = append(src.invitations, invitation)
+		resp.Results = append(resp.Results, &RetryResult{
+			ID:             invitation.ID,
+			OrderID:        invitation.OrderID,
+			GithubUsername: invitation.GithubUsername,
+			Class:          failedClass(invitation),
+			Row:            len(src.invitations),
+			Outcome:        Outcome{Result: OutcomeQueued},
+		})
+	}
+	if len(src.invitations) == 0 {
+		writeJSON(w, r, http.StatusOK, resp)
+		return
+	}
+
+	var run *inviteRun
+	if run, err = startBatch(r.Context(), src, false); err != nil {
+		statusCode = http.StatusInternalServerError
+		if errors.Is(err, ErrBatchRunning) {
+			statusCode = http.StatusConflict
+			err = errors.New("a retry with the same filter is already running")
+		}
+		return
+	}
+	go func() {
+		ctx := context.WithoutCancel(r.Context())
+		if err := run.execute(ctx, src); err != nil {
+			logrus.WithError(err).WithField("jobID", run.batchID).Error("retry source error")
+		}
+		logrus.WithField("jobID", run.batchID).Infof("retry::processed=%d||failed=%d", run.processed(), len(run.failedList))
+	}()
+	resp.JobID = run.batchID
+	resp.Queued = len(src.invitations)
+	writeJSON(w, r, http.StatusAccepted, resp)
+}
+
+// refuseRetry 永久性失败未指定 force 时返回 refused 的结果，可以重试时返回 nil
+func refuseRetry(invitation *model.InvitationModel, force bool) *RetryResult {
+	class := failedClass(invitation)
+	if !slices.Contains(permanentClasses, class) || force {
+		return nil
+	}
+	return &RetryResult{
+		ID:             invitation.ID,
+		OrderID:        invitation.OrderID,
+		GithubUsername: invitation.GithubUsername,
+		Class:          class,
+		Outcome:        Outcome{Result: OutcomeRefused, Class: class, Reason: "permanent error, set force to retry"},
+	}
+}
+
+// retryOne 重新邀请一条 FAILED 记录并回写数据源，永久性失败未指定 force 时不重试
+func retryOne(ctx context.Context, invitation *model.InvitationModel, force bool) *RetryResult {
+	if result := refuseRetry(invitation, force); result != nil {
+		return result
+	}
+	result := &RetryResult{
+		ID:             invitation.ID,
+		OrderID:        invitation.OrderID,
+		GithubUsername: invitation.GithubUsername,
+		Class:          failedClass(invitation),
+	}
+	content := invitationContent(invitation)
+	result.Outcome = inviteOne(ctx, content)
+	writeBackStatus(ctx, content, result.Outcome.writeBackStatus(), result.Outcome.Reason)
+	return result
+}
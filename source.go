@@ -0,0 +1,343 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/model"
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/query"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+)
+
+const (
+	SourceCSV  = "csv"
+	SourceXLSX = "xlsx"
+
+	BatchStatusRunning   = "RUNNING"
+	BatchStatusSucceeded = "SUCCEEDED"
+	BatchStatusFailed    = "FAILED"
+
+	maxImportSize = 32 << 20
+)
+
+// InvitationSource 邀请数据源，按块产出校验过的行和校验失败的行
+type InvitationSource interface {
+	// Name 数据源类型，如 sheet、bitable、csv
+	Name() string
+	// Detail 本次读取的具体对象，如表格范围或上传的文件名
+	Detail() string
+	Each(ctx context.Context, fn func(context.Context, []Range, []InvalidRow) error) error
+}
+
+type sheetSource struct {
+	start, end string
+}
+
+func (s sheetSource) Name() string   { return SourceSheet }
+func (s sheetSource) Detail() string { return s.start + ":" + s.end }
+func (s sheetSource) Each(ctx context.Context, fn func(context.Context, []Range, []InvalidRow) error) error {
+	return SheetRangeEach(ctx, s.start, s.end, fn)
+}
+
+// newInviteSource /invite 和命令行使用的数据源：飞书表格范围或多维表格
+func newInviteSource(source, start, end string) (InvitationSource, error) {
+	switch source {
+	case "", SourceSheet:
+		if start == "" || end == "" {
+			return nil, errors.New("start and end are required for sheet")
+		}
+		return sheetSource{start: start, end: end}, nil
+	case SourceBitable:
+		return bitableSource{}, nil
+	}
+	return nil, fmt.Errorf("unknown source=%s", source)
+}
+
+type bitableSource struct{}
+
+func (bitableSource) Name() string { return SourceBitable }
+func (bitableSource) Detail() string {
+	conf, _ := bitableConfig()
+	return conf.AppToken + "/" + conf.TableID
+}
+func (bitableSource) Each(ctx context.Context, fn func(context.Context, []Range, []InvalidRow) error) error {
+	return BitableEach(ctx, fn)
+}
+
+// fileSource 上传的 CSV/XLSX 文件，列顺序与飞书表格一致：订单号、GitHub 用户名、GitHub 邮箱
+type fileSource struct {
+	format   string
+	fileName string
+	// header 为 true 时第一行是表头，数据从第 2 行开始
+	header bool
+	rows   [][]string
+}
+
+func newFileSource(format, fileName string, data []byte, header bool) (*fileSource, error) {
+	src := &fileSource{format: format, fileName: fileName, header: header}
+	var err error
+	switch format {
+	case SourceCSV:
+		src.rows, err = readCSV(data)
+	case SourceXLSX:
+		src.rows, err = readXLSX(data)
+	default:
+		err = fmt.Errorf("unsupported format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return src, nil
+}
+
+func (s *fileSource) Name() string   { return s.format }
+func (s *fileSource) Detail() string { return s.fileName }
+func (s *fileSource) Each(ctx context.Context, fn func(context.Context, []Range, []InvalidRow) error) error {
+	rows := s.rows
+	firstRow := 1
+	if s.header && len(rows) > 0 {
+		rows = rows[1:]
+		firstRow = 2
+	}
+	for from := 0; from < len(rows); from += sheetChunkRows {
+		chunk := rows[from:min(from+sheetChunkRows, len(rows))]
+		values := make([][]any, 0, len(chunk))
+		for _, row := range chunk {
+			cells := make([]any, len(row))
+			for i, cell := range row {
+				cells[i] = cell
+			}
+			values = append(values, cells)
+		}
+		r, invalid := parseContent(values, firstRow+from)
+		if err := fn(ctx, r, invalid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("read csv error||err=%w", err)
+	}
+	return rows, nil
+}
+
+// runInvitationSource 把数据源中的行全部走一遍邀请流程，并记录为一个批次
+func runInvitationSource(ctx context.Context, src InvitationSource) (*inviteRun, error) {
+	run, err := startBatch(ctx, src, false)
+	if err != nil {
+		return run, err
+	}
+	return run, run.execute(ctx, src)
+}
+
+// ErrBatchRunning 同一数据源、同一范围已有批次在运行
+var ErrBatchRunning = errors.New("a job for this source is already running")
+
+// startBatch 创建 RUNNING 状态的批次，批次 ID 即任务 ID，owner 为当前进程；
+// 同一数据源已有批次在运行时由 batches_running_uidx 拒绝，返回 ErrBatchRunning
+func startBatch(ctx context.Context, src InvitationSource, dryRun bool) (*inviteRun, error) {
+	batch := &model.BatchModel{
+		ID:          uuid.New().String(),
+		Source:      src.Name(),
+		Detail:      src.Detail(),
+		Status:      BatchStatusRunning,
+		DryRun:      dryRun,
+		Owner:       batchOwner,
+		HeartbeatAt: time.Now(),
+	}
+	run := &inviteRun{source: src.Name(), batchID: batch.ID}
+	if dryRun {
+		run.dryRun = newDryRunState()
+	}
+	// 已退出的进程留下的 RUNNING 批次不应挡住新的批次
+	failStaleBatches(ctx)
+	err := query.BatchModel.WithContext(ctx).Create(batch)
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return run, ErrBatchRunning
+	}
+	if err != nil {
+		return run, fmt.Errorf("create_batch_error||err=%v", err)
+	}
+	return run, nil
+}
+
+// execute 处理数据源中的所有行，结束后把批次状态写回去
+func (run *inviteRun) execute(ctx context.Context, src InvitationSource) error {
+	start := time.Now()
+	stop := run.heartbeat(ctx)
+	err := src.Each(ctx, run.process)
+	stop()
+
+	status, cause := BatchStatusSucceeded, ""
+	if err != nil {
+		status, cause = BatchStatusFailed, err.Error()
+	}
+	metricRunDuration.since(start, run.source, strings.ToLower(status), strconv.FormatBool(run.dryRun != nil))
+	// 请求被取消时仍要把批次结果写回去
+	ctx = context.WithoutCancel(ctx)
+	columns := append(run.progress(),
+		query.BatchModel.Status.Value(status),
+		query.BatchModel.Error.Value(cause),
+		query.BatchModel.FinishedAt.Value(time.Now()),
+	)
+	if _, err2 := query.BatchModel.WithContext(ctx).
+		Where(query.BatchModel.ID.Eq(run.batchID)).
+		UpdateColumnSimple(columns...); err2 != nil {
+		logrus.WithField("batchID", run.batchID).WithError(err2).Error("_db_update_batch_error")
+	}
+	publishJobFinish(ctx, run.batchID)
+	return err
+}
+
+// progress 批次中各结果的当前计数
+func (run *inviteRun) progress() []field.AssignExpr {
+	return []field.AssignExpr{
+		query.BatchModel.SucceededCnt.Value(int32(len(run.successList))),
+		query.BatchModel.RenewedCnt.Value(int32(len(run.renewed))),
+		query.BatchModel.FailedCnt.Value(int32(len(run.failedList))),
+		query.BatchModel.SkippedCnt.Value(int32(len(run.skipped))),
+		query.BatchModel.InvalidCnt.Value(int32(len(run.invalid))),
+	}
+}
+
+// saveRow 记录一行的结果并更新批次进度，失败只记日志，不影响邀请
+func (run *inviteRun) saveRow(ctx context.Context, content Range, outcome Outcome) {
+	run.saveRows(ctx, []*model.BatchRowModel{{
+		ID:             uuid.New().String(),
+		BatchID:        run.batchID,
+		RowNumber:      int32(content.Row),
+		RecordID:       content.RecordID,
+		OrderSource:    content.orderSource(),
+		OrderID:        strconv.FormatInt(content.OrderID, 10),
+		GithubUsername: content.GithubUsername,
+		GithubEmail:    content.GithubEmail,
+		Result:         outcome.Result,
+		Action:         outcome.Action,
+		Class:          outcome.Class,
+		Reason:         outcome.Reason,
+	}})
+}
+
+func (run *inviteRun) saveInvalidRows(ctx context.Context, invalid []InvalidRow) {
+	if len(invalid) == 0 {
+		return
+	}
+	rows := make([]*model.BatchRowModel, 0, len(invalid))
+	for _, row := range invalid {
+		rows = append(rows, &model.BatchRowModel{
+			ID:             uuid.New().String(),
+			BatchID:        run.batchID,
+			RowNumber:      int32(row.Row),
+			RecordID:       row.RecordID,
+			OrderID:        row.OrderID,
+			GithubUsername: row.GithubUsername,
+			GithubEmail:    row.GithubEmail,
+			Result:         OutcomeInvalid,
+			Reason:         row.Reason,
+		})
+	}
+	run.saveRows(ctx, rows)
+}
+
+func (run *inviteRun) saveRows(ctx context.Context, rows []*model.BatchRowModel) {
+	dryRun := strconv.FormatBool(run.dryRun != nil)
+	logger := logrus.WithField("batchID", run.batchID)
+	if err := query.BatchRowModel.WithContext(ctx).CreateInBatches(rows, 500); err != nil {
+		logger.WithError(err).Error("_db_create_batch_rows_error")
+	}
+	for _, row := range rows {
+		metricRowsProcessed.inc(run.source, row.Result, dryRun)
+		jobEvents.publish(run.batchID, jobEvent{name: JobEventRow, row: row})
+	}
+	if _, err := query.BatchModel.WithContext(ctx).
+		Where(query.BatchModel.ID.Eq(run.batchID)).
+		UpdateColumnSimple(run.progress()...); err != nil {
+		logger.WithError(err).Error("_db_update_batch_progress_error")
+	}
+}
+
+// imports 上传 CSV/XLSX 文件，走与飞书表格相同的邀请流程
+func imports(w http.ResponseWriter, r *http.Request) {
+	var (
+		err        error
+		statusCode = http.StatusOK
+	)
+	defer func() {
+		if err != nil {
+			writeError(w, r, statusCode, err)
+		}
+	}()
+	if r.Method != http.MethodPost {
+		err = errors.New("method not allowed")
+		statusCode = http.StatusMethodNotAllowed
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	if err = r.ParseMultipartForm(maxImportSize); err != nil {
+		statusCode = http.StatusBadRequest
+		err = fmt.Errorf("parse multipart form error, err=%w", err)
+		return
+	}
+	file, fileHeader, err := r.FormFile("file")
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		err = fmt.Errorf("form file error, err=%w", err)
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		err = fmt.Errorf("read file error, err=%w", err)
+		return
+	}
+
+	format := strings.ToLower(r.FormValue("format"))
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(fileHeader.Filename)), ".")
+	}
+	header := r.FormValue("header") != "false"
+	src, err := newFileSource(format, fileHeader.Filename, data, header)
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		err = fmt.Errorf("invalid file, err=%w", err)
+		return
+	}
+
+	run, err := runInvitationSource(r.Context(), src)
+	if errors.Is(err, ErrBatchRunning) {
+		statusCode = http.StatusConflict
+		return
+	}
+	if err != nil {
+		statusCode = http.StatusOK
+		err = fmt.Errorf("import error, err=%w, processed=%d", err, run.processed())
+		return
+	}
+	if isV1(r) {
+		writeJSON(w, r, http.StatusOK, run.result())
+		return
+	}
+	writeJSON(w, r, http.StatusOK, run.response())
+}
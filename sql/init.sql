@@ -5,7 +5,7 @@ CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
 
 
 DROP TYPE IF EXISTS invitation_status;
-CREATE TYPE invitation_status AS ENUM ('PENDING', 'FAILED', 'SUCCEEDED');
+CREATE TYPE invitation_status AS ENUM ('PENDING', 'FAILED', 'SUCCEEDED', 'IGNORED', 'RESOLVED', 'SUSPECTED_ABUSE', 'EXPIRED', 'REVOKED');
 
 CREATE TABLE auto_org_invitation.invitations (
     id uuid NOT NULL,
@@ -14,11 +14,23 @@ CREATE TABLE auto_org_invitation.invitations (
     github_email CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
     invitation_status invitation_status NOT NULL,
     first_error TEXT NOT NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    -- 最近一次失败的分类，见 outcome.go 中的 Class 常量
+    error_class CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL DEFAULT '',
     created_at TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT 'epoch'::timestamp,
+    record_id CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    -- 订单来源：bilibili 为会员购订单号，afdian 为 afdian_orders.id
+    order_source CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL DEFAULT 'bilibili',
+    -- 到期时间，epoch 表示永久有效
+    expires_at TIMESTAMP WITH TIME ZONE DEFAULT 'epoch'::timestamp,
+    expiry_warned_at TIMESTAMP WITH TIME ZONE DEFAULT 'epoch'::timestamp,
     CONSTRAINT pk PRIMARY KEY (id)
 );
 
+CREATE INDEX invitations_order_id_idx ON auto_org_invitation.invitations (order_id);
+CREATE INDEX invitations_github_username_idx ON auto_org_invitation.invitations (github_username);
+
 CREATE TABLE auto_org_invitation.failed_invitations (
     id uuid NOT NULL,
     order_id BIGINT NOT NULL,
@@ -37,9 +49,175 @@ CREATE TABLE auto_org_invitation.successful_invitations (
     succeeded_at TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
 );
 
+-- 校验未通过的行，同一行同一原因只保留一条
+CREATE TABLE auto_org_invitation.invalid_rows (
+    id uuid NOT NULL,
+    source CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    row_number INTEGER NOT NULL,
+    record_id CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    order_id CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    github_username CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    github_email CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    reason TEXT NOT NULL,
+    first_seen_at TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    last_seen_at TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    CONSTRAINT invalid_rows_pk PRIMARY KEY (id),
+    CONSTRAINT invalid_rows_uk UNIQUE (source, row_number, record_id, order_id, github_username, github_email, reason)
+);
+
+-- 运营在飞书卡片上的操作记录，用于审计
+CREATE TABLE auto_org_invitation.invitation_actions (
+    id uuid NOT NULL,
+    invitation_id uuid NOT NULL,
+    action CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    operator_open_id CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    operator_user_id CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    CONSTRAINT invitation_actions_pk PRIMARY KEY (id)
+);
+
+-- 每次从数据源（飞书表格、多维表格、上传文件）发起的邀请记为一个批次
+CREATE TABLE auto_org_invitation.batches (
+    id uuid NOT NULL,
+    source CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    detail CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    status CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    succeeded_cnt INTEGER NOT NULL DEFAULT 0,
+    failed_cnt INTEGER NOT NULL DEFAULT 0,
+    skipped_cnt INTEGER NOT NULL DEFAULT 0,
+    invalid_cnt INTEGER NOT NULL DEFAULT 0,
+    renewed_cnt INTEGER NOT NULL DEFAULT 0,
+    -- 试运行只记录计划的动作，不邀请、不写 invitations
+    dry_run BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    finished_at TIMESTAMP WITH TIME ZONE DEFAULT 'epoch'::timestamp,
+    -- 运行批次的进程，服务和 invite 命令各自不同；运行中定期更新 heartbeat_at，长时间没有更新的批次视为已中断
+    owner CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL DEFAULT '',
+    heartbeat_at TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    CONSTRAINT batches_pk PRIMARY KEY (id)
+);
+
+-- 同一数据源、同一范围同时只能有一个正在运行的批次，试运行与正式运行分开
+CREATE UNIQUE INDEX batches_running_uidx ON auto_org_invitation.batches (source, detail, dry_run) WHERE status = 'RUNNING';
+
+-- 批次中每一行的处理结果
+CREATE TABLE auto_org_invitation.batch_rows (
+    id uuid NOT NULL,
+    batch_id uuid NOT NULL,
+    row_number INTEGER NOT NULL,
+    record_id CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    order_source CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    order_id CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    github_username CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    github_email CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    result CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    -- 试运行时计划的动作
+    action CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL DEFAULT '',
+    class CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    CONSTRAINT batch_rows_pk PRIMARY KEY (id)
+);
+
+CREATE INDEX batch_rows_batch_id_idx ON auto_org_invitation.batch_rows (batch_id, created_at);
+
+-- 订单核验结果缓存
+CREATE TABLE auto_org_invitation.purchase_verifications (
+    order_id BIGINT NOT NULL,
+    found BOOLEAN NOT NULL,
+    paid BOOLEAN NOT NULL,
+    refunded BOOLEAN NOT NULL,
+    product_id BIGINT NOT NULL,
+    status CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    verified_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT purchase_verifications_pk PRIMARY KEY (order_id)
+);
+
+-- 续费记录，每个订单只能续费一次
+CREATE TABLE auto_org_invitation.renewals (
+    id uuid NOT NULL,
+    invitation_id uuid NOT NULL,
+    order_source CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL DEFAULT 'bilibili',
+    order_id BIGINT NOT NULL,
+    github_username CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    previous_expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    CONSTRAINT renewals_pk PRIMARY KEY (id),
+    CONSTRAINT renewals_order_uk UNIQUE (order_source, order_id)
+);
+
+-- 爱发电订单；out_trade_no 超出 BIGINT，邀请记录以 id 作为订单号
+CREATE TABLE auto_org_invitation.afdian_orders (
+    id BIGSERIAL NOT NULL,
+    out_trade_no CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    user_id CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    plan_id CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    month INTEGER NOT NULL,
+    total_amount CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    status INTEGER NOT NULL,
+    remark TEXT NOT NULL,
+    -- webhook 开始邀请的时间，条件更新保证重复推送只邀请一次；epoch 表示尚未邀请
+    invited_at TIMESTAMP WITH TIME ZONE DEFAULT 'epoch'::timestamp,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    CONSTRAINT afdian_orders_pk PRIMARY KEY (id),
+    CONSTRAINT afdian_orders_out_trade_no_uk UNIQUE (out_trade_no)
+);
+
+-- 每次执行邀请的结果
+CREATE TABLE auto_org_invitation.invitation_attempts (
+    id uuid NOT NULL,
+    invitation_id uuid NOT NULL,
+    status invitation_status NOT NULL,
+    error TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    CONSTRAINT invitation_attempts_pk PRIMARY KEY (id)
+);
+
+CREATE INDEX invitation_attempts_invitation_id_idx ON auto_org_invitation.invitation_attempts (invitation_id);
+CREATE INDEX invitations_github_email_idx ON auto_org_invitation.invitations (lower(github_email));
+
+-- 管理接口的 API key，只保存 SHA-256 摘要
+CREATE TABLE auto_org_invitation.api_keys (
+    id uuid NOT NULL,
+    name CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    prefix CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    key_hash CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    role CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    last_used_at TIMESTAMP WITH TIME ZONE DEFAULT 'epoch'::timestamp,
+    revoked_at TIMESTAMP WITH TIME ZONE DEFAULT 'epoch'::timestamp,
+    CONSTRAINT api_keys_pk PRIMARY KEY (id),
+    CONSTRAINT api_keys_key_hash_uk UNIQUE (key_hash)
+);
+
+-- 封禁名单（list = block）和放行名单（list = allow），kind 为 username、user_id、email、email_domain 或 order_id，
+-- value 统一为小写；order_source 只对 order_id 生效，为空时匹配所有来源
+CREATE TABLE auto_org_invitation.access_rules (
+    id uuid NOT NULL,
+    list CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    kind CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    value CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL,
+    order_source CHARACTER VARYING COLLATE pg_catalog."default" NOT NULL DEFAULT '',
+    reason TEXT NOT NULL DEFAULT '',
+    expires_at TIMESTAMP WITH TIME ZONE DEFAULT 'epoch'::timestamp,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    CONSTRAINT access_rules_pk PRIMARY KEY (id),
+    CONSTRAINT access_rules_uk UNIQUE (list, kind, value, order_source)
+);
+
+CREATE INDEX invitations_expires_at_idx ON auto_org_invitation.invitations (expires_at);
+
+-- 只在状态变为 SUCCEEDED、FAILED 时记一条流水；续费、到期提醒、回填 record_id 等不改状态的更新不记
 CREATE OR REPLACE FUNCTION auto_org_invitation.check_status()
 RETURNS TRIGGER AS $$
 BEGIN
+    IF TG_OP = 'UPDATE' AND OLD.invitation_status IS NOT DISTINCT FROM NEW.invitation_status THEN
+        RETURN NEW;
+    END IF;
     IF NEW.invitation_status = 'SUCCEEDED' THEN
         INSERT INTO auto_org_invitation.successful_invitations (id, order_id, github_username, github_email, invitation_status)
         VALUES (NEW.id, NEW.order_id, NEW.github_username, NEW.github_email, NEW.invitation_status);
@@ -46,7 +46,10 @@ func connectPostgreSQL(v *viper.Viper) *gorm.DB {
 	db, err := gorm.Open(postgres.New(postgres.Config{
 		DSN:                  dsn,
 		PreferSimpleProtocol: true, // disables implicit prepared statement usage
-	}), &gorm.Config{})
+	}), &gorm.Config{
+		// 唯一约束冲突转换为 gorm.ErrDuplicatedKey
+		TranslateError: true,
+	})
 	if err != nil {
 		panic(err)
 	}
@@ -30,6 +30,16 @@ func main() {
 		g.GenerateModelAs("auto_org_invitation.invitations", "InvitationModel"),
 		g.GenerateModelAs("auto_org_invitation.failed_invitations", "FailedInvitationModel"),
 		g.GenerateModelAs("auto_org_invitation.successful_invitations", "SuccessfulInvitationModel"),
+		g.GenerateModelAs("auto_org_invitation.invalid_rows", "InvalidRowModel"),
+		g.GenerateModelAs("auto_org_invitation.invitation_actions", "InvitationActionModel"),
+		g.GenerateModelAs("auto_org_invitation.batches", "BatchModel"),
+		g.GenerateModelAs("auto_org_invitation.batch_rows", "BatchRowModel"),
+		g.GenerateModelAs("auto_org_invitation.purchase_verifications", "PurchaseVerificationModel"),
+		g.GenerateModelAs("auto_org_invitation.renewals", "RenewalModel"),
+		g.GenerateModelAs("auto_org_invitation.afdian_orders", "AfdianOrderModel"),
+		g.GenerateModelAs("auto_org_invitation.api_keys", "APIKeyModel"),
+		g.GenerateModelAs("auto_org_invitation.invitation_attempts", "InvitationAttemptModel"),
+		g.GenerateModelAs("auto_org_invitation.access_rules", "AccessRuleModel"),
 	)
 	g.Execute()
 }
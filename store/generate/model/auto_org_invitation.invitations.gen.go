@@ -18,8 +18,14 @@ type InvitationModel struct {
 	GithubEmail      string    `gorm:"column:github_email;type:character varying;not null" json:"github_email"`
 	InvitationStatus string    `gorm:"column:invitation_status;type:invitation_status;not null" json:"invitation_status"`
 	FirstError       string    `gorm:"column:first_error;type:jsonb;not null" json:"first_error"`
+	LastError        string    `gorm:"column:last_error;type:text;not null" json:"last_error"`
+	ErrorClass       string    `gorm:"column:error_class;type:character varying;not null" json:"error_class"`
 	CreatedAt        time.Time `gorm:"column:created_at;type:timestamp with time zone;default:CURRENT_TIMESTAMP" json:"created_at"`
 	UpdatedAt        time.Time `gorm:"column:updated_at;type:timestamp with time zone;default:1970-01-01 00:00:00" json:"updated_at"`
+	RecordID         string    `gorm:"column:record_id;type:character varying;not null" json:"record_id"`
+	OrderSource      string    `gorm:"column:order_source;type:character varying;not null;default:bilibili" json:"order_source"`
+	ExpiresAt        time.Time `gorm:"column:expires_at;type:timestamp with time zone;default:1970-01-01 00:00:00" json:"expires_at"`
+	ExpiryWarnedAt   time.Time `gorm:"column:expiry_warned_at;type:timestamp with time zone;default:1970-01-01 00:00:00" json:"expiry_warned_at"`
 }
 
 // TableName InvitationModel's table name
@@ -33,8 +33,14 @@ func newInvitationModel(db *gorm.DB, opts ...gen.DOOption) invitationModel {
 	_invitationModel.GithubEmail = field.NewString(tableName, "github_email")
 	_invitationModel.InvitationStatus = field.NewString(tableName, "invitation_status")
 	_invitationModel.FirstError = field.NewString(tableName, "first_error")
+	_invitationModel.LastError = field.NewString(tableName, "last_error")
+	_invitationModel.ErrorClass = field.NewString(tableName, "error_class")
 	_invitationModel.CreatedAt = field.NewTime(tableName, "created_at")
 	_invitationModel.UpdatedAt = field.NewTime(tableName, "updated_at")
+	_invitationModel.RecordID = field.NewString(tableName, "record_id")
+	_invitationModel.OrderSource = field.NewString(tableName, "order_source")
+	_invitationModel.ExpiresAt = field.NewTime(tableName, "expires_at")
+	_invitationModel.ExpiryWarnedAt = field.NewTime(tableName, "expiry_warned_at")
 
 	_invitationModel.fillFieldMap()
 
@@ -51,8 +57,14 @@ type invitationModel struct {
 	GithubEmail      field.String
 	InvitationStatus field.String
 	FirstError       field.String
+	LastError        field.String
+	ErrorClass       field.String
 	CreatedAt        field.Time
 	UpdatedAt        field.Time
+	RecordID         field.String
+	OrderSource      field.String
+	ExpiresAt        field.Time
+	ExpiryWarnedAt   field.Time
 
 	fieldMap map[string]field.Expr
 }
@@ -75,8 +87,14 @@ func (i *invitationModel) updateTableName(table string) *invitationModel {
 	i.GithubEmail = field.NewString(table, "github_email")
 	i.InvitationStatus = field.NewString(table, "invitation_status")
 	i.FirstError = field.NewString(table, "first_error")
+	i.LastError = field.NewString(table, "last_error")
+	i.ErrorClass = field.NewString(table, "error_class")
 	i.CreatedAt = field.NewTime(table, "created_at")
 	i.UpdatedAt = field.NewTime(table, "updated_at")
+	i.RecordID = field.NewString(table, "record_id")
+	i.OrderSource = field.NewString(table, "order_source")
+	i.ExpiresAt = field.NewTime(table, "expires_at")
+	i.ExpiryWarnedAt = field.NewTime(table, "expiry_warned_at")
 
 	i.fillFieldMap()
 
@@ -105,15 +123,21 @@ func (i *invitationModel) GetFieldByName(fieldName string) (field.OrderExpr, boo
 }
 
 func (i *invitationModel) fillFieldMap() {
-	i.fieldMap = make(map[string]field.Expr, 8)
+	i.fieldMap = make(map[string]field.Expr, 14)
 	i.fieldMap["id"] = i.ID
 	i.fieldMap["order_id"] = i.OrderID
 	i.fieldMap["github_username"] = i.GithubUsername
 	i.fieldMap["github_email"] = i.GithubEmail
 	i.fieldMap["invitation_status"] = i.InvitationStatus
 	i.fieldMap["first_error"] = i.FirstError
+	i.fieldMap["last_error"] = i.LastError
+	i.fieldMap["error_class"] = i.ErrorClass
 	i.fieldMap["created_at"] = i.CreatedAt
 	i.fieldMap["updated_at"] = i.UpdatedAt
+	i.fieldMap["record_id"] = i.RecordID
+	i.fieldMap["order_source"] = i.OrderSource
+	i.fieldMap["expires_at"] = i.ExpiresAt
+	i.fieldMap["expiry_warned_at"] = i.ExpiryWarnedAt
 }
 
 func (i invitationModel) clone(db *gorm.DB) invitationModel {
@@ -17,23 +17,53 @@ import (
 
 var (
 	Q                         = new(Query)
+	APIKeyModel               *aPIKeyModel
+	AccessRuleModel           *accessRuleModel
+	AfdianOrderModel          *afdianOrderModel
+	BatchModel                *batchModel
+	BatchRowModel             *batchRowModel
 	FailedInvitationModel     *failedInvitationModel
+	InvalidRowModel           *invalidRowModel
+	InvitationActionModel     *invitationActionModel
+	InvitationAttemptModel    *invitationAttemptModel
 	InvitationModel           *invitationModel
+	PurchaseVerificationModel *purchaseVerificationModel
+	RenewalModel              *renewalModel
 	SuccessfulInvitationModel *successfulInvitationModel
 )
 
 func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
 	*Q = *Use(db, opts...)
+	APIKeyModel = &Q.APIKeyModel
+	AccessRuleModel = &Q.AccessRuleModel
+	AfdianOrderModel = &Q.AfdianOrderModel
+	BatchModel = &Q.BatchModel
+	BatchRowModel = &Q.BatchRowModel
 	FailedInvitationModel = &Q.FailedInvitationModel
+	InvalidRowModel = &Q.InvalidRowModel
+	InvitationActionModel = &Q.InvitationActionModel
+	InvitationAttemptModel = &Q.InvitationAttemptModel
 	InvitationModel = &Q.InvitationModel
+	PurchaseVerificationModel = &Q.PurchaseVerificationModel
+	RenewalModel = &Q.RenewalModel
 	SuccessfulInvitationModel = &Q.SuccessfulInvitationModel
 }
 
 func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 	return &Query{
 		db:                        db,
+		APIKeyModel:               newAPIKeyModel(db, opts...),
+		AccessRuleModel:           newAccessRuleModel(db, opts...),
+		AfdianOrderModel:          newAfdianOrderModel(db, opts...),
+		BatchModel:                newBatchModel(db, opts...),
+		BatchRowModel:             newBatchRowModel(db, opts...),
 		FailedInvitationModel:     newFailedInvitationModel(db, opts...),
+		InvalidRowModel:           newInvalidRowModel(db, opts...),
+		InvitationActionModel:     newInvitationActionModel(db, opts...),
+		InvitationAttemptModel:    newInvitationAttemptModel(db, opts...),
 		InvitationModel:           newInvitationModel(db, opts...),
+		PurchaseVerificationModel: newPurchaseVerificationModel(db, opts...),
+		RenewalModel:              newRenewalModel(db, opts...),
 		SuccessfulInvitationModel: newSuccessfulInvitationModel(db, opts...),
 	}
 }
@@ -41,8 +71,18 @@ func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 type Query struct {
 	db *gorm.DB
 
+	APIKeyModel               aPIKeyModel
+	AccessRuleModel           accessRuleModel
+	AfdianOrderModel          afdianOrderModel
+	BatchModel                batchModel
+	BatchRowModel             batchRowModel
 	FailedInvitationModel     failedInvitationModel
+	InvalidRowModel           invalidRowModel
+	InvitationActionModel     invitationActionModel
+	InvitationAttemptModel    invitationAttemptModel
 	InvitationModel           invitationModel
+	PurchaseVerificationModel purchaseVerificationModel
+	RenewalModel              renewalModel
 	SuccessfulInvitationModel successfulInvitationModel
 }
 
@@ -51,8 +91,18 @@ func (q *Query) Available() bool { return q.db != nil }
 func (q *Query) clone(db *gorm.DB) *Query {
 	return &Query{
 		db:                        db,
+		APIKeyModel:               q.APIKeyModel.clone(db),
+		AccessRuleModel:           q.AccessRuleModel.clone(db),
+		AfdianOrderModel:          q.AfdianOrderModel.clone(db),
+		BatchModel:                q.BatchModel.clone(db),
+		BatchRowModel:             q.BatchRowModel.clone(db),
 		FailedInvitationModel:     q.FailedInvitationModel.clone(db),
+		InvalidRowModel:           q.InvalidRowModel.clone(db),
+		InvitationActionModel:     q.InvitationActionModel.clone(db),
+		InvitationAttemptModel:    q.InvitationAttemptModel.clone(db),
 		InvitationModel:           q.InvitationModel.clone(db),
+		PurchaseVerificationModel: q.PurchaseVerificationModel.clone(db),
+		RenewalModel:              q.RenewalModel.clone(db),
 		SuccessfulInvitationModel: q.SuccessfulInvitationModel.clone(db),
 	}
 }
@@ -68,22 +118,52 @@ func (q *Query) WriteDB() *Query {
 func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 	return &Query{
 		db:                        db,
+		APIKeyModel:               q.APIKeyModel.replaceDB(db),
+		AccessRuleModel:           q.AccessRuleModel.replaceDB(db),
+		AfdianOrderModel:          q.AfdianOrderModel.replaceDB(db),
+		BatchModel:                q.BatchModel.replaceDB(db),
+		BatchRowModel:             q.BatchRowModel.replaceDB(db),
 		FailedInvitationModel:     q.FailedInvitationModel.replaceDB(db),
+		InvalidRowModel:           q.InvalidRowModel.replaceDB(db),
+		InvitationActionModel:     q.InvitationActionModel.replaceDB(db),
+		InvitationAttemptModel:    q.InvitationAttemptModel.replaceDB(db),
 		InvitationModel:           q.InvitationModel.replaceDB(db),
+		PurchaseVerificationModel: q.PurchaseVerificationModel.replaceDB(db),
+		RenewalModel:              q.RenewalModel.replaceDB(db),
 		SuccessfulInvitationModel: q.SuccessfulInvitationModel.replaceDB(db),
 	}
 }
 
 type queryCtx struct {
+	APIKeyModel               IAPIKeyModelDo
+	AccessRuleModel           IAccessRuleModelDo
+	AfdianOrderModel          IAfdianOrderModelDo
+	BatchModel                IBatchModelDo
+	BatchRowModel             IBatchRowModelDo
 	FailedInvitationModel     IFailedInvitationModelDo
+	InvalidRowModel           IInvalidRowModelDo
+	InvitationActionModel     IInvitationActionModelDo
+	InvitationAttemptModel    IInvitationAttemptModelDo
 	InvitationModel           IInvitationModelDo
+	PurchaseVerificationModel IPurchaseVerificationModelDo
+	RenewalModel              IRenewalModelDo
 	SuccessfulInvitationModel ISuccessfulInvitationModelDo
 }
 
 func (q *Query) WithContext(ctx context.Context) *queryCtx {
 	return &queryCtx{
+		APIKeyModel:               q.APIKeyModel.WithContext(ctx),
+		AccessRuleModel:           q.AccessRuleModel.WithContext(ctx),
+		AfdianOrderModel:          q.AfdianOrderModel.WithContext(ctx),
+		BatchModel:                q.BatchModel.WithContext(ctx),
+		BatchRowModel:             q.BatchRowModel.WithContext(ctx),
 		FailedInvitationModel:     q.FailedInvitationModel.WithContext(ctx),
+		InvalidRowModel:           q.InvalidRowModel.WithContext(ctx),
+		InvitationActionModel:     q.InvitationActionModel.WithContext(ctx),
+		InvitationAttemptModel:    q.InvitationAttemptModel.WithContext(ctx),
 		InvitationModel:           q.InvitationModel.WithContext(ctx),
+		PurchaseVerificationModel: q.PurchaseVerificationModel.WithContext(ctx),
+		RenewalModel:              q.RenewalModel.WithContext(ctx),
 		SuccessfulInvitationModel: q.SuccessfulInvitationModel.WithContext(ctx),
 	}
 }
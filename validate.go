@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/mail"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/model"
+	"github.com/Nicknamezz00/org-invitation-autobot/store/generate/query"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cast"
+	"gorm.io/gorm/clause"
+)
+
+// GitHub 用户名：1-39 位字母数字或单个连字符，不能以连字符开头或结尾
+var githubUsernamePattern = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9]|-[A-Za-z0-9]){0,38}$`)
+
+// InvalidRow 校验未通过的行，其余行照常处理
+type InvalidRow struct {
+	Row            int    `json:"row,omitempty"`
+	RecordID       string `json:"record_id,omitempty"`
+	OrderID        string `json:"order_id"`
+	GithubUsername string `json:"github_username"`
+	GithubEmail    string `json:"github_email"`
+	Reason         string `json:"reason"`
+}
+
+// rawRow 从数据源读出、尚未校验的一行
+type rawRow struct {
+	Row            int
+	RecordID       string
+	OrderID        any
+	GithubUsername string
+	GithubEmail    string
+	ExpiresAt      any
+	// OrderSource 为空时为会员购订单
+	OrderSource string
+}
+
+func (raw rawRow) empty() bool {
+	return strings.TrimSpace(cast.ToString(raw.OrderID)) == "" &&
+		strings.TrimSpace(raw.GithubUsername) == "" &&
+		strings.TrimSpace(raw.GithubEmail) == ""
+}
+
+func (raw rawRow) invalid(reason string) InvalidRow {
+	return InvalidRow{
+		Row:            raw.Row,
+		RecordID:       raw.RecordID,
+		OrderID:        cast.ToString(raw.OrderID),
+		GithubUsername: raw.GithubUsername,
+		GithubEmail:    raw.GithubEmail,
+		Reason:         reason,
+	}
+}
+
+// validateRow 校验单行；ok 为 false 且 invalid 为空表示空行，直接忽略
+func validateRow(raw rawRow) (r Range, invalid *InvalidRow, ok bool) {
+	if raw.empty() {
+		return r, nil, false
+	}
+	reject := func(reason string) (Range, *InvalidRow, bool) {
+		row := raw.invalid(reason)
+		return r, &row, false
+	}
+
+	orderID, err := parseOrderID(raw.OrderID)
+	if err != nil {
+		return reject(err.Error())
+	}
+	username := strings.TrimSpace(raw.GithubUsername)
+	if username == "" {
+		return reject("github username is empty")
+	}
+	if !githubUsernamePattern.MatchString(username) || strings.Contains(username, "--") {
+		return reject(fmt.Sprintf("invalid github username %q", username))
+	}
+	// 爱发电订单只有留言，没有邮箱时按 GitHub 用户 ID 邀请
+	email := strings.TrimSpace(raw.GithubEmail)
+	if email == "" && raw.OrderSource != OrderSourceAfdian {
+		return reject("github email is empty")
+	}
+	if addr, err := mail.ParseAddress(email); email != "" && (err != nil || addr.Address != email) {
+		return reject(fmt.Sprintf("invalid github email %q", email))
+	}
+	expiresAt, err := parseExpiry(raw.ExpiresAt)
+	if err != nil {
+		return reject(err.Error())
+	}
+
+	return Range{
+		Row:            raw.Row,
+		RecordID:       raw.RecordID,
+		OrderID:        orderID,
+		GithubUsername: username,
+		GithubEmail:    email,
+		ExpiresAt:      expiresAt,
+		OrderSource:    raw.OrderSource,
+	}, nil, true
+}
+
+// 表格日期序列号的起点，与 Excel 一致
+var sheetSerialEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.Local)
+
+// parseExpiry 解析到期时间单元格，空值返回零值；
+// 支持日期文本、表格日期序列号和多维表格日期字段的毫秒时间戳，只有日期时当天仍有效
+func parseExpiry(v any) (time.Time, error) {
+	s := strings.TrimSpace(cast.ToString(v))
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		switch {
+		case f > 1e11:
+			return time.UnixMilli(int64(f)), nil
+		case f > 0:
+			t := sheetSerialEpoch.Add(time.Duration(f * float64(24*time.Hour)))
+			if f == float64(int64(f)) {
+				t = t.AddDate(0, 0, 1)
+			}
+			return t, nil
+		}
+		return time.Time{}, fmt.Errorf("invalid expiry %q", s)
+	}
+	for _, layout := range []string{time.RFC3339, time.DateTime, "2006/01/02 15:04:05"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	for _, layout := range []string{time.DateOnly, "2006/1/2", "2006.1.2"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t.AddDate(0, 0, 1), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid expiry %q", s)
+}
+
+// maxExactFloat float64 能精确表示所有整数的上限
+const maxExactFloat = 1 << 53
+
+// parseOrderID 数字单元格可能以浮点数返回，超过 2^53 的浮点数已经丢了精度，要求把单元格设为文本
+func parseOrderID(v any) (int64, error) {
+	s := strings.TrimSpace(cast.ToString(v))
+	if s == "" {
+		return 0, fmt.Errorf("order id is empty")
+	}
+	if f, ok := v.(float64); ok && math.Abs(f) >= maxExactFloat {
+		return 0, fmt.Errorf("invalid order id %q: too large for a number cell, format the cell as text", s)
+	}
+	orderID, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		f, ferr := strconv.ParseFloat(s, 64)
+		if ferr != nil || math.Abs(f) >= maxExactFloat || f != math.Trunc(f) {
+			return 0, fmt.Errorf("invalid order id %q", s)
+		}
+		orderID = int64(f)
+	}
+	if orderID <= 0 {
+		return 0, fmt.Errorf("invalid order id %q", s)
+	}
+	return orderID, nil
+}
+
+// saveInvalidRows 记录校验失败的行；同一行同一原因重复出现时只刷新 last_seen_at
+func saveInvalidRows(ctx context.Context, source string, rows []InvalidRow) {
+	if len(rows) == 0 {
+		return
+	}
+	now := time.Now()
+	models := make([]*model.InvalidRowModel, 0, len(rows))
+	for _, row := range rows {
+		models = append(models, &model.InvalidRowModel{
+			ID:             uuid.New().String(),
+			Source:         source,
+			RowNumber:      int32(row.Row),
+			RecordID:       row.RecordID,
+			OrderID:        row.OrderID,
+			GithubUsername: row.GithubUsername,
+			GithubEmail:    row.GithubEmail,
+			Reason:         row.Reason,
+			FirstSeenAt:    now,
+			LastSeenAt:     now,
+		})
+	}
+	t := query.InvalidRowModel
+	err := t.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: t.Source.ColumnName().String()},
+			{Name: t.RowNumber.ColumnName().String()},
+			{Name: t.RecordID.ColumnName().String()},
+			{Name: t.OrderID.ColumnName().String()},
+			{Name: t.GithubUsername.ColumnName().String()},
+			{Name: t.GithubEmail.ColumnName().String()},
+			{Name: t.Reason.ColumnName().String()},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{t.LastSeenAt.ColumnName().String()}),
+	}).Create(models...)
+	if err != nil {
+		logrus.WithError(err).WithField("count", len(rows)).Error("save_invalid_rows_error")
+	}
+}
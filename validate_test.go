@@ -0,0 +1,125 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseOrderID(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       any
+		want    int64
+		wantErr bool
+	}{
+		{name: "text", v: " 123 ", want: 123},
+		{name: "int", v: int64(456), want: 456},
+		{name: "float cell", v: float64(789), want: 789},
+		{name: "float text", v: "789.0", want: 789},
+		{name: "largest exact float", v: float64(1<<53 - 1), want: 1<<53 - 1},
+		{name: "large id as text", v: "12345678901234567", want: 12345678901234567},
+		{name: "float too large", v: float64(1 << 53), wantErr: true},
+		{name: "float in exponent form", v: "1.2345678901234567e16", wantErr: true},
+		{name: "fraction", v: 1.5, wantErr: true},
+		{name: "empty", v: " ", wantErr: true},
+		{name: "zero", v: "0", wantErr: true},
+		{name: "negative", v: "-1", wantErr: true},
+		{name: "not a number", v: "abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseOrderID(tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseOrderID(%v) error = %v, wantErr %v", tt.v, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseOrderID(%v) = %d, want %d", tt.v, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseExpiry(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       any
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "empty", v: ""},
+		{name: "date includes the whole day", v: "2026-03-01", want: time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)},
+		{name: "slash date", v: "2026/3/1", want: time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)},
+		{name: "datetime", v: "2026-03-01 12:30:00", want: time.Date(2026, 3, 1, 12, 30, 0, 0, time.Local)},
+		{name: "rfc3339", v: "2026-03-01T12:30:00Z", want: time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC)},
+		{name: "sheet serial date", v: float64(46082), want: time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)},
+		{name: "sheet serial datetime", v: 46082.5, want: time.Date(2026, 3, 1, 12, 0, 0, 0, time.Local)},
+		{name: "bitable milliseconds", v: int64(1772368200000), want: time.UnixMilli(1772368200000)},
+		{name: "negative", v: "-1", wantErr: true},
+		{name: "garbage", v: "next year", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseExpiry(tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseExpiry(%v) error = %v, wantErr %v", tt.v, err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseExpiry(%v) = %v, want %v", tt.v, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateRow(t *testing.T) {
+	tests := []struct {
+		name   string
+		raw    rawRow
+		want   Range
+		reason string
+		empty  bool
+	}{
+		{
+			name: "valid",
+			raw:  rawRow{Row: 2, OrderID: "42", GithubUsername: " octo-cat ", GithubEmail: "octo@example.com"},
+			want: Range{Row: 2, OrderID: 42, GithubUsername: "octo-cat", GithubEmail: "octo@example.com"},
+		},
+		{
+			name: "afdian without email",
+			raw:  rawRow{Row: 3, OrderID: "42", GithubUsername: "octocat", OrderSource: OrderSourceAfdian},
+			want: Range{Row: 3, OrderID: 42, GithubUsername: "octocat", OrderSource: OrderSourceAfdian},
+		},
+		{name: "empty row", raw: rawRow{Row: 4, GithubUsername: " "}, empty: true},
+		{name: "missing order", raw: rawRow{GithubUsername: "octocat", GithubEmail: "octo@example.com"}, reason: "order id is empty"},
+		{name: "missing username", raw: rawRow{OrderID: "1", GithubEmail: "octo@example.com"}, reason: "github username is empty"},
+		{name: "double hyphen", raw: rawRow{OrderID: "1", GithubUsername: "octo--cat", GithubEmail: "octo@example.com"}, reason: `invalid github username "octo--cat"`},
+		{name: "trailing hyphen", raw: rawRow{OrderID: "1", GithubUsername: "octocat-", GithubEmail: "octo@example.com"}, reason: `invalid github username "octocat-"`},
+		{name: "missing email", raw: rawRow{OrderID: "1", GithubUsername: "octocat"}, reason: "github email is empty"},
+		{name: "email with name", raw: rawRow{OrderID: "1", GithubUsername: "octocat", GithubEmail: "Octo <octo@example.com>"}, reason: `invalid github email "Octo <octo@example.com>"`},
+		{name: "bad expiry", raw: rawRow{OrderID: "1", GithubUsername: "octocat", GithubEmail: "octo@example.com", ExpiresAt: "soon"}, reason: `invalid expiry "soon"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, invalid, ok := validateRow(tt.raw)
+			switch {
+			case tt.empty:
+				if ok || invalid != nil {
+					t.Fatalf("validateRow() = %+v, %+v, %v, want an ignored empty row", got, invalid, ok)
+				}
+			case tt.reason != "":
+				if ok || invalid == nil {
+					t.Fatalf("validateRow() = %+v, %v, want invalid row %q", got, ok, tt.reason)
+				}
+				if invalid.Reason != tt.reason || invalid.Row != tt.raw.Row {
+					t.Errorf("invalid = %+v, want reason %q", invalid, tt.reason)
+				}
+			default:
+				if !ok || invalid != nil {
+					t.Fatalf("validateRow() invalid = %+v, want ok", invalid)
+				}
+				if got != tt.want {
+					t.Errorf("validateRow() = %+v, want %+v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// readXLSX 读取 xlsx 第一个工作表的全部单元格文本，只依赖标准库，
+// 支持共享字符串、内联字符串和数字单元格，足够覆盖订单导出表
+func readXLSX(data []byte) ([][]string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("open xlsx error||err=%w", err)
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	sheetPath, err := xlsxFirstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+	var sharedStrings []string
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		if sharedStrings, err = xlsxSharedStrings(f); err != nil {
+			return nil, err
+		}
+	}
+	f, ok := files[sheetPath]
+	if !ok {
+		return nil, fmt.Errorf("xlsx sheet not found||path=%s", sheetPath)
+	}
+	return xlsxSheetRows(f, sharedStrings)
+}
+
+func xlsxDecode(f *zip.File, v any) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	if err = xml.NewDecoder(rc).Decode(v); err != nil {
+		return fmt.Errorf("decode %s error||err=%w", f.Name, err)
+	}
+	return nil
+}
+
+func xlsxFirstSheetPath(files map[string]*zip.File) (string, error) {
+	var workbook struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+			RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	f, ok := files["xl/workbook.xml"]
+	if !ok {
+		return "", errors.New("xl/workbook.xml not found")
+	}
+	if err := xlsxDecode(f, &workbook); err != nil {
+		return "", err
+	}
+	if len(workbook.Sheets) == 0 {
+		return "", errors.New("xlsx has no sheets")
+	}
+
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if f, ok := files["xl/_rels/workbook.xml.rels"]; ok {
+		if err := xlsxDecode(f, &rels); err != nil {
+			return "", err
+		}
+	}
+	for _, rel := range rels.Relationships {
+		if rel.ID != workbook.Sheets[0].RID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+	return "xl/worksheets/sheet1.xml", nil
+}
+
+type xlsxRichText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxRichText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var sb strings.Builder
+	for _, r := range t.Runs {
+		sb.WriteString(r.T)
+	}
+	return sb.String()
+}
+
+func xlsxSharedStrings(f *zip.File) ([]string, error) {
+	var sst struct {
+		Items []xlsxRichText `xml:"si"`
+	}
+	if err := xlsxDecode(f, &sst); err != nil {
+		return nil, err
+	}
+	r := make([]string, 0, len(sst.Items))
+	for _, item := range sst.Items {
+		r = append(r, item.String())
+	}
+	return r, nil
+}
+
+const (
+	// 行号、列号上限与 Excel 相同，超出的单元格引用视为文件损坏
+	xlsxMaxRows    = 1 << 20
+	xlsxMaxColumns = 1 << 14
+	// xlsxMaxCells 按行号、列号补齐后的单元格总数上限，防止稀疏的大引用撑爆内存
+	xlsxMaxCells = 1 << 22
+)
+
+func xlsxSheetRows(f *zip.File, sharedStrings []string) ([][]string, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	type cell struct {
+		Ref    string       `xml:"r,attr"`
+		Type   string       `xml:"t,attr"`
+		Value  string       `xml:"v"`
+		Inline xlsxRichText `xml:"is"`
+	}
+	type row struct {
+		Num   int    `xml:"r,attr"`
+		Cells []cell `xml:"c"`
+	}
+
+	var (
+		rows  [][]string
+		cells int
+	)
+	decoder := xml.NewDecoder(rc)
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decode %s error||err=%w", f.Name, err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "row" {
+			continue
+		}
+		var rr row
+		if err = decoder.DecodeElement(&rr, &start); err != nil {
+			return nil, fmt.Errorf("decode %s row error||err=%w", f.Name, err)
+		}
+		// 空行不会出现在 sheetData 中，按行号补齐，保证行号与表格一致
+		num := rr.Num
+		if num == 0 {
+			num = len(rows) + 1
+		}
+		if num < 0 || num > xlsxMaxRows {
+			return nil, fmt.Errorf("invalid row number||row=%d", rr.Num)
+		}
+		for len(rows) < num-1 {
+			rows = append(rows, nil)
+		}
+		var values []string
+		for _, c := range rr.Cells {
+			// 没有引用的单元格紧跟在前一个之后
+			col := len(values)
+			if c.Ref != "" {
+				name, _ := splitCell(c.Ref)
+				col = xlsxColumnIndex(name)
+			}
+			if col < 0 || col >= xlsxMaxColumns {
+				return nil, fmt.Errorf("invalid cell reference||cell=%s", c.Ref)
+			}
+			if col >= len(values) {
+				if cells += col + 1 - len(values); cells > xlsxMaxCells {
+					return nil, fmt.Errorf("too many cells||limit=%d", xlsxMaxCells)
+				}
+				values = append(values, make([]string, col+1-len(values))...)
+			}
+			switch c.Type {
+			case "s":
+				idx, err := strconv.Atoi(c.Value)
+				if err != nil || idx < 0 || idx >= len(sharedStrings) {
+					return nil, fmt.Errorf("invalid shared string index||cell=%s", c.Ref)
+				}
+				values[col] = sharedStrings[idx]
+			case "inlineStr":
+				values[col] = c.Inline.String()
+			default:
+				values[col] = c.Value
+			}
+		}
+		rows = append(rows, values)
+	}
+	return rows, nil
+}
+
+// xlsxColumnIndex A -> 0，Z -> 25，AA -> 26；不是 1 到 3 个字母时返回 -1
+func xlsxColumnIndex(name string) int {
+	if name == "" || len(name) > 3 {
+		return -1
+	}
+	idx := 0
+	for _, ch := range name {
+		if ch < 'A' || ch > 'Z' {
+			return -1
+		}
+		idx = idx*26 + int(ch-'A'+1)
+	}
+	return idx - 1
+}
+
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`
+)
+
+// xlsxWriter 流式写出只有一个工作表的 xlsx：工作表放在 zip 的最后，逐行写入，不在内存中保留整张表；
+// 单元格都写成内联字符串，数字写成数字单元格
+type xlsxWriter struct {
+	zw    *zip.Writer
+	sheet io.Writer
+}
+
+func newXLSXWriter(w io.Writer) (*xlsxWriter, error) {
+	zw := zip.NewWriter(w)
+	for _, part := range []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	} {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = io.WriteString(f, part.content); err != nil {
+			return nil, err
+		}
+	}
+	sheet, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err = io.WriteString(sheet, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`+"\n"+
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`); err != nil {
+		return nil, err
+	}
+	return &xlsxWriter{zw: zw, sheet: sheet}, nil
+}
+
+// WriteRow 值为 int64 时写数字单元格，其余按字符串写
+func (x *xlsxWriter) WriteRow(values []any) error {
+	var sb strings.Builder
+	sb.WriteString("<row>")
+	for _, v := range values {
+		if n, ok := v.(int64); ok {
+			sb.WriteString(`<c t="n"><v>` + strconv.FormatInt(n, 10) + `</v></c>`)
+			continue
+		}
+		sb.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+		if err := xml.EscapeText(&sb, []byte(fmt.Sprint(v))); err != nil {
+			return err
+		}
+		sb.WriteString(`</t></is></c>`)
+	}
+	sb.WriteString("</row>")
+	_, err := io.WriteString(x.sheet, sb.String())
+	return err
+}
+
+func (x *xlsxWriter) Close() error {
+	if _, err := io.WriteString(x.sheet, `</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	return x.zw.Close()
+}